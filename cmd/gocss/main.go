@@ -11,6 +11,7 @@ import (
 	"github.com/su3h7am/gocss/pkg/core"
 	"github.com/su3h7am/gocss/pkg/extractor"
 	"github.com/su3h7am/gocss/pkg/preset"
+	"github.com/su3h7am/gocss/pkg/transformer"
 )
 
 func main() {
@@ -34,9 +35,15 @@ func main() {
 			&extractor.ExtractorSplit{},
 			&extractor.TemplExtractor{},
 		},
+		Transformers: []core.Transformer{
+			&transformer.VariantGroup{},
+		},
 	}
 
-	resolvedConfig := core.NewResolvedConfig(cfg)
+	resolvedConfig, err := core.NewResolvedConfig(cfg)
+	if err != nil {
+		log.Fatalf("Error resolving config: %v", err)
+	}
 	generator := core.NewGenerator(resolvedConfig)
 
 	// Build function
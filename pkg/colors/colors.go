@@ -0,0 +1,383 @@
+// Package colors faz a leitura e conversão de cores CSS usadas pelos presets.
+//
+// Cores concretas (hex, rgb, hsl, oklch e cores nomeadas) são convertidas para
+// sRGB ou OKLCH; cores que só podem ser resolvidas pelo navegador, como
+// `var(--x)` e `currentColor`, são mantidas como expressões e recebem a
+// opacidade através de `color-mix()`.
+package colors
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Format define o formato de saída das cores.
+type Format int
+
+const (
+	// FormatHex gera `#rrggbb`, ou `rgb(r g b / a)` quando há opacidade.
+	FormatHex Format = iota
+	// FormatRGB gera sempre `rgb(r g b)` ou `rgb(r g b / a)`.
+	FormatRGB
+	// FormatOKLCH gera `oklch(L C H)` ou `oklch(L C H / a)`.
+	FormatOKLCH
+)
+
+// Space indica o espaço de cor em que os componentes de uma Color estão.
+type Space int
+
+const (
+	// SpaceRaw é usado para expressões que não podem ser convertidas (ex.: `var(--x)`).
+	SpaceRaw Space = iota
+	// SpaceSRGB guarda os componentes R, G e B entre 0 e 1.
+	SpaceSRGB
+	// SpaceOKLCH guarda L (0..1), C e H (graus).
+	SpaceOKLCH
+)
+
+// Color é uma cor lida de uma string CSS.
+type Color struct {
+	Space      Space
+	Components [3]float64
+	Alpha      float64
+	Raw        string // Expressão original quando Space é SpaceRaw
+}
+
+// Parse lê uma cor CSS. São aceitos hex (#rgb, #rgba, #rrggbb, #rrggbbaa),
+// rgb()/rgba(), hsl()/hsla(), oklch(), cores nomeadas, `transparent`,
+// `currentColor`, `var(...)` e as demais funções de cor CSS (mantidas como estão).
+func Parse(s string) (Color, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Color{}, false
+	}
+	if strings.HasPrefix(s, "#") {
+		return parseHex(s[1:])
+	}
+
+	lower := strings.ToLower(s)
+	switch lower {
+	case "transparent", "currentcolor", "inherit":
+		return Color{Space: SpaceRaw, Raw: s, Alpha: 1}, true
+	}
+	if hex, ok := namedColors[lower]; ok {
+		return parseHex(hex[1:])
+	}
+
+	// Cores que dependem de variáveis só podem ser resolvidas pelo navegador
+	if strings.Contains(lower, "var(") || strings.Contains(lower, "<alpha-value>") {
+		return Color{Space: SpaceRaw, Raw: s, Alpha: 1}, true
+	}
+
+	name, args, ok := splitFunction(lower)
+	if !ok {
+		return Color{}, false
+	}
+	switch name {
+	case "rgb", "rgba":
+		return parseRGB(args)
+	case "hsl", "hsla":
+		return parseHSL(args)
+	case "oklch":
+		return parseOKLCH(args)
+	case "color-mix", "color", "hwb", "lab", "lch", "oklab":
+		return Color{Space: SpaceRaw, Raw: s, Alpha: 1}, true
+	}
+	return Color{}, false
+}
+
+// WithAlpha retorna uma cópia da cor com a opacidade multiplicada por alpha.
+func (c Color) WithAlpha(alpha float64) Color {
+	c.Alpha *= alpha
+	return c
+}
+
+// Opaque informa se a cor não tem transparência aplicada.
+func (c Color) Opaque() bool {
+	return c.Alpha >= 1
+}
+
+// CSS serializa a cor no formato pedido.
+func (c Color) CSS(f Format) string {
+	switch c.Space {
+	case SpaceRaw:
+		return c.rawCSS(f)
+	case SpaceOKLCH:
+		if f == FormatOKLCH {
+			return c.oklchCSS()
+		}
+		c = c.ToSRGB()
+	case SpaceSRGB:
+		if f == FormatOKLCH {
+			return c.ToOKLCH().oklchCSS()
+		}
+	}
+
+	r, g, b := to255(c.Components[0]), to255(c.Components[1]), to255(c.Components[2])
+	if f == FormatHex && c.Opaque() {
+		return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+	}
+	if c.Opaque() {
+		return fmt.Sprintf("rgb(%d %d %d)", r, g, b)
+	}
+	return fmt.Sprintf("rgb(%d %d %d / %s)", r, g, b, formatFloat(c.Alpha, 3))
+}
+
+func (c Color) rawCSS(f Format) string {
+	if c.Opaque() || strings.EqualFold(c.Raw, "transparent") {
+		return strings.ReplaceAll(c.Raw, "<alpha-value>", "1")
+	}
+	// Suporte ao marcador <alpha-value> do Tailwind: `rgb(var(--brand) / <alpha-value>)`
+	if strings.Contains(c.Raw, "<alpha-value>") {
+		return strings.ReplaceAll(c.Raw, "<alpha-value>", formatFloat(c.Alpha, 3))
+	}
+	space := "srgb"
+	if f == FormatOKLCH {
+		space = "oklab"
+	}
+	return fmt.Sprintf("color-mix(in %s, %s %s%%, transparent)", space, c.Raw, formatFloat(c.Alpha*100, 2))
+}
+
+func (c Color) oklchCSS() string {
+	l, ch, h := c.Components[0], c.Components[1], c.Components[2]
+	s := fmt.Sprintf("oklch(%s%% %s %s", formatFloat(l*100, 2), formatFloat(ch, 4), formatFloat(h, 2))
+	if !c.Opaque() {
+		s += " / " + formatFloat(c.Alpha, 3)
+	}
+	return s + ")"
+}
+
+// ToSRGB converte a cor para sRGB, limitando os componentes ao intervalo visível.
+func (c Color) ToSRGB() Color {
+	if c.Space != SpaceOKLCH {
+		return c
+	}
+	l, ch, h := c.Components[0], c.Components[1], c.Components[2]
+	hr := h * math.Pi / 180
+	a, b := ch*math.Cos(hr), ch*math.Sin(hr)
+
+	lp := l + 0.3963377774*a + 0.2158037573*b
+	mp := l - 0.1055613458*a - 0.0638541728*b
+	sp := l - 0.0894841775*a - 1.2914855480*b
+	lc, mc, sc := lp*lp*lp, mp*mp*mp, sp*sp*sp
+
+	rl := 4.0767416621*lc - 3.3077115913*mc + 0.2309699292*sc
+	gl := -1.2684380046*lc + 2.6097574011*mc - 0.3413193965*sc
+	bl := -0.0041960863*lc - 0.7034186147*mc + 1.7076147010*sc
+
+	return Color{
+		Space:      SpaceSRGB,
+		Components: [3]float64{clamp(fromLinear(rl)), clamp(fromLinear(gl)), clamp(fromLinear(bl))},
+		Alpha:      c.Alpha,
+	}
+}
+
+// ToOKLCH converte uma cor sRGB para OKLCH.
+func (c Color) ToOKLCH() Color {
+	if c.Space != SpaceSRGB {
+		return c
+	}
+	r, g, b := toLinear(c.Components[0]), toLinear(c.Components[1]), toLinear(c.Components[2])
+
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+
+	L := 0.2104542553*l + 0.7936177850*m - 0.0040720468*s
+	A := 1.9779984951*l - 2.4285922050*m + 0.4505937099*s
+	B := 0.0259040371*l + 0.7827717662*m - 0.8086757660*s
+
+	ch := math.Hypot(A, B)
+	h := math.Atan2(B, A) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	if ch < 1e-4 {
+		ch, h = 0, 0
+	}
+	return Color{Space: SpaceOKLCH, Components: [3]float64{L, ch, h}, Alpha: c.Alpha}
+}
+
+func parseHex(hex string) (Color, bool) {
+	switch len(hex) {
+	case 3, 4:
+		expanded := make([]byte, 0, len(hex)*2)
+		for i := 0; i < len(hex); i++ {
+			expanded = append(expanded, hex[i], hex[i])
+		}
+		hex = string(expanded)
+	case 6, 8:
+	default:
+		return Color{}, false
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return Color{}, false
+	}
+	alpha := 1.0
+	if len(hex) == 8 {
+		alpha = float64(v&0xff) / 255
+		v >>= 8
+	}
+	return Color{
+		Space:      SpaceSRGB,
+		Components: [3]float64{float64(v>>16) / 255, float64(v>>8&0xff) / 255, float64(v&0xff) / 255},
+		Alpha:      alpha,
+	}, true
+}
+
+func parseRGB(args []string) (Color, bool) {
+	if len(args) != 3 && len(args) != 4 {
+		return Color{}, false
+	}
+	var c [3]float64
+	for i := 0; i < 3; i++ {
+		v, ok := parseComponent(args[i], 255)
+		if !ok {
+			return Color{}, false
+		}
+		c[i] = clamp(v)
+	}
+	alpha, ok := parseAlpha(args)
+	return Color{Space: SpaceSRGB, Components: c, Alpha: alpha}, ok
+}
+
+func parseHSL(args []string) (Color, bool) {
+	if len(args) != 3 && len(args) != 4 {
+		return Color{}, false
+	}
+	h, ok := parseHue(args[0])
+	if !ok {
+		return Color{}, false
+	}
+	s, ok1 := parseComponent(args[1], 100)
+	l, ok2 := parseComponent(args[2], 100)
+	if !ok1 || !ok2 {
+		return Color{}, false
+	}
+	s, l = clamp(s), clamp(l)
+
+	f := func(n float64) float64 {
+		k := math.Mod(n+h/30, 12)
+		a := s * math.Min(l, 1-l)
+		return l - a*math.Max(-1, math.Min(math.Min(k-3, 9-k), 1))
+	}
+	alpha, ok := parseAlpha(args)
+	return Color{Space: SpaceSRGB, Components: [3]float64{f(0), f(8), f(4)}, Alpha: alpha}, ok
+}
+
+func parseOKLCH(args []string) (Color, bool) {
+	if len(args) != 3 && len(args) != 4 {
+		return Color{}, false
+	}
+	l, ok := parseComponent(args[0], 1)
+	if !ok {
+		return Color{}, false
+	}
+	// Para o croma, 100% equivale a 0.4
+	ch, ok := parseComponent(args[1], 1)
+	if !ok {
+		return Color{}, false
+	}
+	if strings.HasSuffix(args[1], "%") {
+		ch *= 0.4
+	}
+	h, ok := parseHue(args[2])
+	if !ok {
+		return Color{}, false
+	}
+	alpha, ok := parseAlpha(args)
+	return Color{Space: SpaceOKLCH, Components: [3]float64{l, ch, h}, Alpha: alpha}, ok
+}
+
+// splitFunction separa "rgb(1 2 3 / 0.5)" em "rgb" e ["1", "2", "3", "0.5"].
+func splitFunction(s string) (string, []string, bool) {
+	open := strings.IndexByte(s, '(')
+	if open <= 0 || !strings.HasSuffix(s, ")") {
+		return "", nil, false
+	}
+	body := s[open+1 : len(s)-1]
+	body = strings.NewReplacer(",", " ", "/", " / ").Replace(body)
+	fields := strings.Fields(body)
+
+	args := make([]string, 0, 4)
+	for i, f := range fields {
+		if f == "/" {
+			// A opacidade só pode aparecer como último argumento
+			if i != len(fields)-2 {
+				return "", nil, false
+			}
+			continue
+		}
+		args = append(args, f)
+	}
+	return s[:open], args, true
+}
+
+// parseComponent lê um número ou porcentagem; números são divididos por scale.
+func parseComponent(s string, scale float64) (float64, bool) {
+	if strings.HasSuffix(s, "%") {
+		v, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+		return v / 100, err == nil
+	}
+	if s == "none" {
+		return 0, true
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	return v / scale, err == nil
+}
+
+func parseHue(s string) (float64, bool) {
+	factor := 1.0
+	switch {
+	case strings.HasSuffix(s, "deg"):
+		s = strings.TrimSuffix(s, "deg")
+	case strings.HasSuffix(s, "turn"):
+		s, factor = strings.TrimSuffix(s, "turn"), 360
+	case strings.HasSuffix(s, "rad"):
+		s, factor = strings.TrimSuffix(s, "rad"), 180/math.Pi
+	}
+	if s == "none" {
+		return 0, true
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	return v * factor, err == nil
+}
+
+func parseAlpha(args []string) (float64, bool) {
+	if len(args) < 4 {
+		return 1, true
+	}
+	v, ok := parseComponent(args[3], 1)
+	return clamp(v), ok
+}
+
+func toLinear(c float64) float64 {
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+func fromLinear(c float64) float64 {
+	if c <= 0.0031308 {
+		return 12.92 * c
+	}
+	return 1.055*math.Pow(c, 1/2.4) - 0.055
+}
+
+func clamp(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+func to255(v float64) int {
+	return int(math.Round(clamp(v) * 255))
+}
+
+// formatFloat arredonda para no máximo prec casas decimais, sem zeros à direita.
+func formatFloat(v float64, prec int) string {
+	p := math.Pow(10, float64(prec))
+	return strconv.FormatFloat(math.Round(v*p)/p, 'f', -1, 64)
+}
@@ -0,0 +1,64 @@
+package colors
+
+import "testing"
+
+func TestParseAndFormat(t *testing.T) {
+	tests := []struct {
+		in     string
+		alpha  float64
+		format Format
+		want   string
+	}{
+		{"#ef4444", 1, FormatHex, "#ef4444"},
+		{"#fff", 1, FormatHex, "#ffffff"},
+		{"#ef4444", 0.5, FormatHex, "rgb(239 68 68 / 0.5)"},
+		{"#ef444480", 1, FormatRGB, "rgb(239 68 68 / 0.502)"},
+		{"rgb(255, 0, 0)", 1, FormatHex, "#ff0000"},
+		{"rgba(255, 0, 0, 0.5)", 1, FormatHex, "rgb(255 0 0 / 0.5)"},
+		{"rgb(0 128 255 / 50%)", 1, FormatRGB, "rgb(0 128 255 / 0.5)"},
+		{"hsl(0 100% 50%)", 1, FormatHex, "#ff0000"},
+		{"hsl(120deg, 100%, 25%)", 1, FormatHex, "#008000"},
+		{"rebeccapurple", 1, FormatHex, "#663399"},
+		{"White", 0.25, FormatRGB, "rgb(255 255 255 / 0.25)"},
+		{"#ffffff", 1, FormatOKLCH, "oklch(100% 0 0)"},
+		{"#000", 0.5, FormatOKLCH, "oklch(0% 0 0 / 0.5)"},
+		{"oklch(62.8% 0.2577 29.23)", 1, FormatHex, "#ff0000"},
+		{"oklch(0.628 0.2577 29.23)", 1, FormatOKLCH, "oklch(62.8% 0.2577 29.23)"},
+		{"oklch(62.8% 0.2577 29.23)", 0.5, FormatOKLCH, "oklch(62.8% 0.2577 29.23 / 0.5)"},
+		{"var(--brand)", 1, FormatHex, "var(--brand)"},
+		{"var(--brand)", 0.5, FormatHex, "color-mix(in srgb, var(--brand) 50%, transparent)"},
+		{"var(--brand)", 0.5, FormatOKLCH, "color-mix(in oklab, var(--brand) 50%, transparent)"},
+		{"rgb(var(--brand) / <alpha-value>)", 0.35, FormatHex, "rgb(var(--brand) / 0.35)"},
+		{"rgb(var(--brand) / <alpha-value>)", 1, FormatHex, "rgb(var(--brand) / 1)"},
+		{"currentColor", 0.25, FormatHex, "color-mix(in srgb, currentColor 25%, transparent)"},
+		{"transparent", 0.5, FormatHex, "transparent"},
+	}
+
+	for _, tt := range tests {
+		c, ok := Parse(tt.in)
+		if !ok {
+			t.Errorf("Parse(%q) failed", tt.in)
+			continue
+		}
+		if got := c.WithAlpha(tt.alpha).CSS(tt.format); got != tt.want {
+			t.Errorf("Parse(%q).WithAlpha(%v).CSS(%v) = %q, want %q", tt.in, tt.alpha, tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, in := range []string{"", "#12", "#ggg", "rgb(1 2)", "hsl(a b c)", "url(a.png)", "notacolor", "10px"} {
+		if _, ok := Parse(in); ok {
+			t.Errorf("Parse(%q) succeeded, want failure", in)
+		}
+	}
+}
+
+func TestOKLCHRoundTrip(t *testing.T) {
+	for _, hex := range []string{"#ef4444", "#3b82f6", "#22c55e", "#64748b", "#fef2f2"} {
+		c, _ := Parse(hex)
+		if got := c.ToOKLCH().ToSRGB().CSS(FormatHex); got != hex {
+			t.Errorf("round trip of %s = %s", hex, got)
+		}
+	}
+}
@@ -2,20 +2,34 @@ package core
 
 // Config é a estrutura de configuração que o usuário define.
 type Config struct {
-	Rules      []Rule
-	Shortcuts  []Shortcut
-	Variants   []Variant
-	Extractors []Extractor
-	Preflights []Preflight
-	Layers     map[string]int
-	Presets    []Preset
+	Rules        []Rule
+	Shortcuts    []Shortcut
+	Variants     []Variant
+	Extractors   []Extractor
+	Transformers []Transformer
+	Preflights   []Preflight
+	Layers       map[string]int
+	Presets      []Preset
+	Theme        map[string]interface{}
+
+	// CustomVariants define variantes a partir de templates CSS, por nome
+	// (ex.: {"theme-x": "&:is(.theme-x *)", "hocus": "&:hover, &:focus"}).
+	// Veja VariantFromTemplate.
+	CustomVariants map[string]string
+
+	// CustomShortcuts define atalhos a partir de strings, por nome; chaves
+	// iniciadas por "^" são expressões regulares com $1 no template
+	// (ex.: {"btn": "py-2 px-4 hover:bg-blue-600", "^btn-(\\w+)$": "bg-$1-500"}).
+	// Veja ShortcutsFromMap.
+	CustomShortcuts map[string]string
 }
 
 // Preset é uma função que aplica uma configuração pré-definida.
 type Preset func(config *ResolvedConfig)
 
 // NewResolvedConfig cria uma nova instância de ResolvedConfig aplicando presets e configurações do usuário.
-func NewResolvedConfig(cfg *Config) *ResolvedConfig {
+// Retorna erro se alguma definição em texto da configuração (ex.: CustomVariants) for inválida.
+func NewResolvedConfig(cfg *Config) (*ResolvedConfig, error) {
 	resolved := &ResolvedConfig{
 		Theme:         make(map[string]interface{}),
 		Rules:         []Rule{},
@@ -23,6 +37,7 @@ func NewResolvedConfig(cfg *Config) *ResolvedConfig {
 		Shortcuts:     []Shortcut{},
 		Preflights:    []Preflight{},
 		Extractors:    []Extractor{},
+		Transformers:  []Transformer{},
 		Layers:        make(map[string]int),
 		Postprocess:   []Postprocessor{},
 	}
@@ -34,18 +49,48 @@ func NewResolvedConfig(cfg *Config) *ResolvedConfig {
 
 	// Merge user's config (user config overrides presets)
 	resolved.Rules = append(resolved.Rules, cfg.Rules...)
+	// Atalhos do usuário vêm antes dos do preset: o primeiro que corresponder vence
+	customShortcuts, err := ShortcutsFromMap(cfg.CustomShortcuts)
+	if err != nil {
+		return nil, err
+	}
+	shortcuts := append(append([]Shortcut{}, cfg.Shortcuts...), customShortcuts...)
+	resolved.Shortcuts = append(shortcuts, resolved.Shortcuts...)
 	resolved.Variants = append(resolved.Variants, cfg.Variants...)
+	customVariants, err := templateVariants(cfg.CustomVariants)
+	if err != nil {
+		return nil, err
+	}
+	resolved.Variants = append(resolved.Variants, customVariants...)
 	resolved.Preflights = append(resolved.Preflights, cfg.Preflights...)
 	resolved.Extractors = append(resolved.Extractors, cfg.Extractors...)
+	resolved.Transformers = append(resolved.Transformers, cfg.Transformers...)
 
 	// Merge layers (user layers override preset layers)
 	for k, v := range cfg.Layers {
 		resolved.Layers[k] = v
 	}
 
-	// TODO: Merge shortcuts, theme, postprocess, etc.
+	// Merge theme (user theme keys are merged deeply over preset theme)
+	mergeTheme(resolved.Theme, cfg.Theme)
 
-	return resolved
+	// TODO: Merge postprocess, etc.
+
+	return resolved, nil
+}
+
+// mergeTheme mescla src em dst recursivamente. Mapas aninhados são mesclados
+// chave a chave; qualquer outro valor de src substitui o valor de dst.
+func mergeTheme(dst, src map[string]interface{}) {
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[string]interface{})
+		dstMap, dstIsMap := dst[k].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeTheme(dstMap, srcMap)
+			continue
+		}
+		dst[k] = v
+	}
 }
 
 // NewGenerator cria uma nova instância do UnoGenerator com a configuração resolvida.
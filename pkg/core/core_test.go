@@ -1,6 +1,8 @@
 package core
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strings"
@@ -205,4 +207,690 @@ func TestSortLayers(t *testing.T) {
 		if !reflect.DeepEqual(sorted, expectedOrder) {
 			t.Errorf("Expected sorted layers %v, got %v", expectedOrder, sorted)
 	}
-}
\ No newline at end of file
+}
+func TestParseTokenRuleFallthrough(t *testing.T) {
+	cfg := &ResolvedConfig{
+		Rules: []Rule{
+			{
+				Matcher: regexp.MustCompile(`^text-(.+)$`),
+				Handler: func(match []string, ctx *RuleContext) *CSSEntry {
+					if match[1] != "red" {
+						return nil
+					}
+					return &CSSEntry{Properties: map[string]string{"color": "red"}}
+				},
+				Meta: &RuleMeta{Layer: "utilities"},
+			},
+			{
+				Matcher: regexp.MustCompile(`^text-(.+)$`),
+				Handler: func(match []string, ctx *RuleContext) *CSSEntry {
+					return &CSSEntry{Properties: map[string]string{"font-size": match[1]}}
+				},
+				Meta: &RuleMeta{Layer: "utilities"},
+			},
+		},
+	}
+	generator := NewGenerator(cfg)
+
+	utils, err := generator.ParseToken("text-red")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(utils) != 1 || utils[0].Entries["color"] != "red" {
+		t.Errorf("Expected color rule for text-red, got %v", utils)
+	}
+
+	utils, err = generator.ParseToken("text-[2rem]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(utils) != 1 || utils[0].Entries["font-size"] != "[2rem]" {
+		t.Fatalf("Expected fallthrough to font-size rule, got %v", utils)
+	}
+	if utils[0].Selector != `.text-\[2rem\]` {
+		t.Errorf("Expected escaped default selector, got %s", utils[0].Selector)
+	}
+}
+
+func TestEscapeSelector(t *testing.T) {
+	tests := map[string]string{
+		"m-4":                `m-4`,
+		"hover:text-red-500": `hover\:text-red-500`,
+		"bg-red-500/50":      `bg-red-500\/50`,
+		"w-[0.35rem]":        `w-\[0\.35rem\]`,
+		"2xl:p-4":            `\32 xl\:p-4`,
+		"-mt-2":              `-mt-2`,
+		"bg-[#fff]":          `bg-\[\#fff\]`,
+	}
+	for in, want := range tests {
+		if got := EscapeSelector(in); got != want {
+			t.Errorf("EscapeSelector(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestNewResolvedConfigMergesTheme(t *testing.T) {
+	preset := func(config *ResolvedConfig) {
+		config.Theme["colors"] = map[string]interface{}{
+			"red":   map[string]interface{}{"500": "#ef4444", "600": "#dc2626"},
+			"white": "#fff",
+		}
+	}
+	resolved, err := NewResolvedConfig(&Config{
+		Presets: []Preset{preset},
+		Theme: map[string]interface{}{
+			"colors": map[string]interface{}{
+				"red":   map[string]interface{}{"500": "#f00"},
+				"brand": "#123456",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	colors := resolved.Theme["colors"].(map[string]interface{})
+	red := colors["red"].(map[string]interface{})
+	if red["500"] != "#f00" || red["600"] != "#dc2626" {
+		t.Errorf("Expected red to be merged deeply, got %v", red)
+	}
+	if colors["white"] != "#fff" || colors["brand"] != "#123456" {
+		t.Errorf("Expected white and brand colors, got %v", colors)
+	}
+}
+
+func TestGeneratePreflights(t *testing.T) {
+	cfg := &ResolvedConfig{
+		Rules: []Rule{
+			{
+				Static:  "block",
+				Handler: func(match []string, ctx *RuleContext) *CSSEntry { return &CSSEntry{Properties: map[string]string{"display": "block"}} },
+				Meta:    &RuleMeta{Layer: "utilities"},
+			},
+		},
+		Preflights: []Preflight{
+			{Layer: "base", GetCSS: func(ctx *PreflightContext) string { return "html {\n  line-height: 1.5;\n}" }},
+			{GetCSS: func(ctx *PreflightContext) string { return "" }},
+			{Layer: "base"},
+		},
+		Extractors: []Extractor{splitExtractor{}},
+		Layers:     map[string]int{"base": 0, "utilities": 1},
+	}
+	generator := NewGenerator(cfg)
+
+	css, err := generator.Generate(map[string]string{"index.html": "block"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "@layer base {\n  html {\n    line-height: 1.5;\n  }\n}\n" +
+		"@layer utilities {\n    .block {\n      display: block;\n    }\n}\n"
+	if css != expected {
+		t.Errorf("Expected CSS:\n%s\ngot:\n%s", expected, css)
+	}
+}
+
+// splitExtractor separa o código por espaços, como o extractor.ExtractorSplit.
+type splitExtractor struct{}
+
+func (splitExtractor) Extract(code string, path string) []string {
+	return strings.Fields(code)
+}
+
+func TestGenerateNestedParents(t *testing.T) {
+	cfg := &ResolvedConfig{
+		Rules: []Rule{
+			{
+				Static: "box",
+				Handler: func(match []string, ctx *RuleContext) *CSSEntry {
+					return &CSSEntry{
+						Properties: map[string]string{"width": "100%"},
+						Extra: []*CSSEntry{
+							{Properties: map[string]string{"max-width": "640px"}, Parent: "@media (min-width: 640px)"},
+						},
+					}
+				},
+				Meta: &RuleMeta{Layer: "utilities"},
+			},
+		},
+		Variants: []Variant{
+			{
+				Matcher: func(token string, ctx *VariantContext) *VariantMatch {
+					if strings.HasPrefix(token, "print:") {
+						return &VariantMatch{Matcher: "print:"}
+					}
+					return nil
+				},
+				Handler: func(entry *CSSEntry, match *VariantMatch) *CSSEntry {
+					entry.Parent = NestParent("@media print", entry.Parent)
+					return entry
+				},
+			},
+		},
+		Extractors: []Extractor{splitExtractor{}},
+		Layers:     map[string]int{"utilities": 0},
+	}
+	generator := NewGenerator(cfg)
+
+	css, err := generator.Generate(map[string]string{"index.html": "print:box"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "@layer utilities {\n" +
+		"  @media print {\n    .print\\:box {\n      width: 100%;\n    }\n  }\n" +
+		"  @media print {\n    @media (min-width: 640px) {\n      .print\\:box {\n        max-width: 640px;\n      }\n    }\n  }\n" +
+		"}\n"
+	if css != expected {
+		t.Errorf("Expected CSS:\n%s\ngot:\n%s", expected, css)
+	}
+}
+
+func TestSortParents(t *testing.T) {
+	parents := []string{
+		"@supports (display: grid)",
+		"@media (min-width: 1024px)",
+		"@media (min-width: 48rem)",
+		"_default",
+		"@media (max-width: calc(768px - 0.1px))",
+		"@media not all and (min-width: 1280px)",
+		"@media (min-width: 640px) $$ @media print",
+		"@media (min-width: 1024px) $$ @media (min-width: 1536px)",
+		"@media (min-width: 1024px) $$ @media (min-width: 640px)",
+		"@media (min-width: 1024px) $$ @media (min-width: 1280px)",
+	}
+	sortParents(parents)
+
+	expected := []string{
+		"_default",
+		"@media not all and (min-width: 1280px)",
+		"@media (max-width: calc(768px - 0.1px))",
+		"@media (min-width: 640px) $$ @media print",
+		"@media (min-width: 48rem)",
+		"@media (min-width: 1024px) $$ @media (min-width: 640px)",
+		"@media (min-width: 1024px)",
+		"@media (min-width: 1024px) $$ @media (min-width: 1280px)",
+		"@media (min-width: 1024px) $$ @media (min-width: 1536px)",
+		"@supports (display: grid)",
+	}
+	if !reflect.DeepEqual(parents, expected) {
+		t.Errorf("sortParents() = %v, want %v", parents, expected)
+	}
+}
+
+func TestExpandVariantGroup(t *testing.T) {
+	tests := []struct {
+		input      string
+		separators []string
+		expected   string
+	}{
+		{"hover:(bg-blue-500 text-white)", nil, "hover:bg-blue-500 hover:text-white"},
+		{"sm:(p-4 m-2) block", nil, "sm:p-4 sm:m-2 block"},
+		{"md:hover:(underline font-bold)", nil, "md:hover:underline md:hover:font-bold"},
+		{"text-(red-500 lg)", nil, "text-red-500 text-lg"},
+		{"border-(~ red-500)", nil, "border border-red-500"},
+		{"lg:(p-4 hover:(bg-red-500 text-white))", nil, "lg:p-4 lg:hover:bg-red-500 lg:hover:text-white"},
+		{"dark:(\n  bg-black\n  text-white\n)", nil, "dark:bg-black dark:text-white"},
+		{"group-[.is-active]:(flex gap-2)", nil, "group-[.is-active]:flex group-[.is-active]:gap-2"},
+		{"hover:(bg-[#fff] w-[calc(100%-2rem)])", nil, "hover:bg-[#fff] hover:w-[calc(100%-2rem)]"},
+		{"hover:(bg-[#fff] w-1/2)", nil, "hover:bg-[#fff] hover:w-1/2"},
+		{"text-(red-500 lg)", []string{":"}, "text-(red-500 lg)"},
+		{`<div class="p-4">(no group)</div>`, nil, `<div class="p-4">(no group)</div>`},
+	}
+
+	for _, tt := range tests {
+		if got := ExpandVariantGroup(tt.input, tt.separators...); got != tt.expected {
+			t.Errorf("ExpandVariantGroup(%q, %v) = %q, want %q", tt.input, tt.separators, got, tt.expected)
+		}
+	}
+}
+
+func TestVariantOrderNormalization(t *testing.T) {
+	prefixVariant := func(prefix, pseudo string, order int) Variant {
+		return Variant{
+			Matcher: func(token string, ctx *VariantContext) *VariantMatch {
+				if strings.HasPrefix(token, prefix) {
+					return &VariantMatch{Matcher: prefix}
+				}
+				return nil
+			},
+			Handler: func(entry *CSSEntry, match *VariantMatch) *CSSEntry {
+				entry.Selector += pseudo
+				return entry
+			},
+			Order: order,
+		}
+	}
+	cfg := &ResolvedConfig{
+		Rules: []Rule{
+			{
+				Static:  "block",
+				Handler: func(match []string, ctx *RuleContext) *CSSEntry { return &CSSEntry{Properties: map[string]string{"display": "block"}} },
+			},
+		},
+		Variants: []Variant{
+			prefixVariant("hover:", ":hover", 1),
+			prefixVariant("focus:", ":focus", 2),
+		},
+	}
+	generator := NewGenerator(cfg)
+
+	for _, token := range []string{"hover:focus:block", "focus:hover:block"} {
+		utils, _ := generator.ParseToken(token)
+		if len(utils) != 1 {
+			t.Fatalf("ParseToken(%q) returned %d utils", token, len(utils))
+		}
+		expected := "." + EscapeSelector(token) + ":hover:focus"
+		if utils[0].Selector != expected {
+			t.Errorf("ParseToken(%q) selector = %q, want %q", token, utils[0].Selector, expected)
+		}
+		if !reflect.DeepEqual(utils[0].VariantOrder, []int{2, 1}) {
+			t.Errorf("ParseToken(%q) variant order = %v, want [2 1]", token, utils[0].VariantOrder)
+		}
+	}
+
+	// Uma variante com Wrap não é reordenada: as demais são normalizadas
+	// apenas dentro de cada trecho.
+	child := prefixVariant("child:", "", 3)
+	child.Handler = func(entry *CSSEntry, match *VariantMatch) *CSSEntry {
+		entry.Selector = ":is(" + entry.Selector + " > *)"
+		return entry
+	}
+	child.Wrap = true
+	generator = NewGenerator(&ResolvedConfig{Rules: cfg.Rules, Variants: append(cfg.Variants, child)})
+	for token, want := range map[string]string{
+		"child:hover:block":             `:is(.child\:hover\:block:hover > *)`,
+		"hover:child:block":             `:is(.hover\:child\:block > *):hover`,
+		"hover:child:focus:hover:block": `:is(.hover\:child\:focus\:hover\:block:hover:focus > *):hover`,
+	} {
+		utils, _ := generator.ParseToken(token)
+		if len(utils) != 1 || utils[0].Selector != want {
+			t.Errorf("ParseToken(%q) = %v, want selector %q", token, utils, want)
+		}
+	}
+
+	utils := []*StringifiedUtil{
+		{Selector: ".c", VariantOrder: []int{2, 1}},
+		{Selector: ".b", VariantOrder: []int{2}},
+		{Selector: ".z", RuleIndex: 1},
+		{Selector: ".a", VariantOrder: []int{1}},
+		{Selector: ".y", RuleIndex: 0},
+	}
+	sortUtils(utils)
+	var got []string
+	for _, u := range utils {
+		got = append(got, u.Selector)
+	}
+	if expected := []string{".y", ".z", ".a", ".b", ".c"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("sortUtils() = %v, want %v", got, expected)
+	}
+}
+
+func TestVariantFromTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		selector string
+		parent   string
+	}{
+		{"theme-x", "&:is(.theme-x *)", `.theme-x\:block:is(.theme-x *)`, ""},
+		{"hocus", "&:hover, &:focus", `:is(.hocus\:block:hover, .hocus\:block:focus)`, ""},
+		{"dir", "&:where([dir=rtl], [dir=rtl] *)", `.dir\:block:where([dir=rtl], [dir=rtl] *)`, ""},
+		{"can-hover", "@media (hover: hover) { &:hover }", `.can-hover\:block:hover`, "@media (hover: hover)"},
+		{"print-grid", "@media print { @supports (display: grid) { & } }", `.print-grid\:block`, "@media print $$ @supports (display: grid)"},
+		{"print", "@media print", `.print\:block`, "@media print"},
+		{"checked", ":checked", `.checked\:block:checked`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := VariantFromTemplate(tt.name, tt.template)
+			if err != nil {
+				t.Fatal(err)
+			}
+			token := tt.name + ":block"
+			match := v.Matcher(token, &VariantContext{})
+			if match == nil || match.Matcher != tt.name+":" {
+				t.Fatalf("Matcher(%q) = %v", token, match)
+			}
+			entry := v.Handler(&CSSEntry{Selector: "." + EscapeSelector(token)}, match)
+			if entry.Selector != tt.selector {
+				t.Errorf("selector = %q, want %q", entry.Selector, tt.selector)
+			}
+			if entry.Parent != tt.parent {
+				t.Errorf("parent = %q, want %q", entry.Parent, tt.parent)
+			}
+		})
+	}
+
+	for _, template := range []string{"@media print { &", "&:is(.a { b })"} {
+		if _, err := VariantFromTemplate("bad", template); err == nil {
+			t.Errorf("VariantFromTemplate(%q) expected error", template)
+		}
+	}
+}
+
+func TestNewResolvedConfigCustomVariants(t *testing.T) {
+	cfg, err := NewResolvedConfig(&Config{
+		Rules: []Rule{
+			{
+				Static:  "block",
+				Handler: func(match []string, ctx *RuleContext) *CSSEntry { return &CSSEntry{Properties: map[string]string{"display": "block"}} },
+			},
+		},
+		CustomVariants: map[string]string{
+			"theme-x": "&:is(.theme-x *)",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Variants) != 1 {
+		t.Fatalf("Expected 1 variant, got %d", len(cfg.Variants))
+	}
+
+	utils, _ := NewGenerator(cfg).ParseToken("theme-x:block")
+	if len(utils) != 1 || utils[0].Selector != `.theme-x\:block:is(.theme-x *)` {
+		t.Errorf("ParseToken(theme-x:block) = %v", utils)
+	}
+
+	_, err = NewResolvedConfig(&Config{CustomVariants: map[string]string{"bad": "@media print {"}})
+	if err == nil || !strings.Contains(err.Error(), `variant "bad"`) {
+		t.Errorf("NewResolvedConfig() error = %v, want invalid variant error", err)
+	}
+}
+
+func TestCompileRule(t *testing.T) {
+	theme := map[string]interface{}{
+		"spacing": map[string]interface{}{"4": "1rem"},
+	}
+	tests := []struct {
+		def     RuleDefinition
+		token   string
+		entries map[string]string
+	}{
+		{RuleDefinition{Name: "block", Properties: map[string]string{"display": "block"}}, "block", map[string]string{"display": "block"}},
+		{RuleDefinition{Pattern: `^m-(.+)$`, Theme: "spacing", Properties: map[string]string{"margin": "$1"}}, "m-4", map[string]string{"margin": "1rem"}},
+		{RuleDefinition{Pattern: `^m-(.+)$`, Theme: "spacing", Properties: map[string]string{"margin": "$1"}}, "m-[3px_auto]", map[string]string{"margin": "3px auto"}},
+		{RuleDefinition{Pattern: `^m-(.+)$`, Theme: "spacing", Properties: map[string]string{"margin": "$1"}}, "m-99", nil},
+		{RuleDefinition{Pattern: `^object-(left|right)-(top|bottom)$`, Properties: map[string]string{"object-position": "$1 $2"}}, "object-left-top", map[string]string{"object-position": "left top"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			rule, err := CompileRule(tt.def)
+			if err != nil {
+				t.Fatal(err)
+			}
+			match := []string{tt.token}
+			if rule.Matcher != nil {
+				if match = rule.Matcher.FindStringSubmatch(tt.token); match == nil {
+					t.Fatalf("pattern did not match %q", tt.token)
+				}
+			}
+			entry := rule.Handler(match, &RuleContext{RawSelector: tt.token, Theme: theme})
+			if tt.entries == nil {
+				if entry != nil {
+					t.Errorf("entry = %v, want nil", entry.Properties)
+				}
+				return
+			}
+			if entry == nil || !reflect.DeepEqual(entry.Properties, tt.entries) {
+				t.Errorf("entry = %v, want %v", entry, tt.entries)
+			}
+		})
+	}
+
+	for _, def := range []RuleDefinition{
+		{Properties: map[string]string{"display": "block"}},
+		{Name: "a", Pattern: "^a$", Properties: map[string]string{"display": "block"}},
+		{Name: "block"},
+		{Pattern: "^(a$", Properties: map[string]string{"display": "block"}},
+	} {
+		if _, err := CompileRule(def); err == nil {
+			t.Errorf("CompileRule(%+v) expected error", def)
+		}
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	jsonData := `{"layer": "utilities", "rules": [
+		{"name": "html", "selector": "html", "layer": "base", "properties": {"box-sizing": "border-box"}},
+		{"pattern": "^gap-(\\d+)$", "properties": {"gap": "$1px"}}
+	]}`
+	yamlData := `layer: utilities
+rules:
+  - {name: html, selector: html, layer: base, properties: {box-sizing: border-box}}
+  - {pattern: '^gap-(\d+)$', properties: {gap: $1px}}
+`
+	dir := t.TempDir()
+	for name, data := range map[string]string{"rules.json": jsonData, "rules.yml": yamlData} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			rules, err := LoadRulesFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rules) != 2 {
+				t.Fatalf("got %d rules, want 2", len(rules))
+			}
+			if rules[0].Meta.Layer != "base" || rules[1].Meta.Layer != "utilities" {
+				t.Errorf("layers = %q, %q", rules[0].Meta.Layer, rules[1].Meta.Layer)
+			}
+			if entry := rules[0].Handler([]string{"html"}, &RuleContext{}); entry.Selector != "html" {
+				t.Errorf("selector = %q, want html", entry.Selector)
+			}
+			match := rules[1].Matcher.FindStringSubmatch("gap-2")
+			if entry := rules[1].Handler(match, &RuleContext{}); entry.Properties["gap"] != "2px" {
+				t.Errorf("gap = %q, want 2px", entry.Properties["gap"])
+			}
+		})
+	}
+
+	if _, err := LoadRulesFile(filepath.Join(dir, "rules.toml")); err == nil {
+		t.Error("LoadRulesFile expected error for missing file")
+	}
+	path := filepath.Join(dir, "rules.txt")
+	os.WriteFile(path, []byte(jsonData), 0o644)
+	if _, err := LoadRulesFile(path); err == nil || !strings.Contains(err.Error(), "unsupported") {
+		t.Errorf("LoadRulesFile(%q) error = %v, want unsupported", path, err)
+	}
+	if _, err := LoadRulesYAML([]byte("rules: [{name: a}]")); err == nil {
+		t.Error("LoadRulesYAML expected error for rule without properties")
+	}
+}
+
+func TestShortcutLayer(t *testing.T) {
+	static := func(name, prop, value string) Rule {
+		return Rule{
+			Static: name,
+			Handler: func(match []string, ctx *RuleContext) *CSSEntry {
+				return &CSSEntry{Properties: map[string]string{prop: value}}
+			},
+			Meta: &RuleMeta{Layer: "utilities"},
+		}
+	}
+	cfg, err := NewResolvedConfig(&Config{
+		Rules: []Rule{
+			static("px-4", "padding-inline", "1rem"),
+			static("font-bold", "font-weight", "700"),
+			static("font-light", "font-weight", "300"),
+		},
+		Shortcuts: []Shortcut{
+			{Static: "btn", Expand: func([]string) []string { return []string{"px-4", "font-light", "font-bold"} }},
+			{Static: "card", Expand: func([]string) []string { return []string{"btn"} }, Meta: &RuleMeta{Layer: "shortcuts"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGenerator(cfg)
+
+	tests := []struct {
+		token    string
+		selector string
+		layer    string
+	}{
+		{"btn", ".btn", DefaultShortcutLayer},
+		{"card", ".card", "shortcuts"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			utils, err := g.ParseToken(tt.token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(utils) != 1 {
+				t.Fatalf("got %d utils, want 1 merged util", len(utils))
+			}
+			util := utils[0]
+			if util.Selector != tt.selector || util.Layer != tt.layer {
+				t.Errorf("got %q in layer %q, want %q in layer %q", util.Selector, util.Layer, tt.selector, tt.layer)
+			}
+			want := map[string]string{"padding-inline": "1rem", "font-weight": "700"}
+			if !reflect.DeepEqual(util.Entries, want) {
+				t.Errorf("entries = %v, want %v", util.Entries, want)
+			}
+		})
+	}
+
+	// Os utilitários dos membros continuam intactos no cache.
+	if utils, _ := g.ParseToken("font-light"); utils[0].Entries["font-weight"] != "300" || utils[0].Layer != "utilities" {
+		t.Errorf("font-light = %v in %q", utils[0].Entries, utils[0].Layer)
+	}
+	// Um atalho na camada das regras é escrito depois delas.
+	cfg, err = NewResolvedConfig(&Config{
+		Rules:      cfg.Rules,
+		Shortcuts:  []Shortcut{{Static: "bold", Expand: func([]string) []string { return []string{"font-bold"} }, Meta: &RuleMeta{Layer: "utilities"}}},
+		Extractors: []Extractor{splitExtractor{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	css, err := NewGenerator(cfg).Generate(map[string]string{"index.html": "bold font-light"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Index(css, ".bold {") < strings.Index(css, ".font-light {") {
+		t.Errorf("Expected .bold after .font-light:\n%s", css)
+	}
+}
+
+func TestShortcutTemplates(t *testing.T) {
+	fromMap, err := ShortcutsFromMap(map[string]string{
+		"^btn-(\\w+)$": "bg-$1-500 hover:bg-$1-600",
+		"btn":          "py-2  px-4 hover:(font-bold underline)",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	custom := Shortcut{Static: "card", Expand: func([]string) []string { return []string{"p-4"} }}
+	shortcuts, err := ParseShortcuts(fromMap, custom, map[string]string{"^chip-(?P<color>\\w+)$": "text-${color}-800"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGenerator(&ResolvedConfig{Shortcuts: shortcuts})
+
+	tests := []struct {
+		token    string
+		expanded []string
+	}{
+		{"btn", []string{"py-2", "px-4", "hover:font-bold", "hover:underline"}},
+		{"btn-red", []string{"bg-red-500", "hover:bg-red-600"}},
+		{"card", []string{"p-4"}},
+		{"chip-green", []string{"text-green-800"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			ok, expanded, err := g.expandShortcut(tt.token)
+			if err != nil || !ok {
+				t.Fatalf("expandShortcut(%q) = %v, %v", tt.token, ok, err)
+			}
+			if !reflect.DeepEqual(expanded, tt.expanded) {
+				t.Errorf("expanded = %v, want %v", expanded, tt.expanded)
+			}
+		})
+	}
+
+	if _, err := ShortcutsFromMap(map[string]string{"^btn-(": "x"}); err == nil {
+		t.Error("ShortcutsFromMap expected error for invalid pattern")
+	}
+	if _, err := ParseShortcuts([]string{"btn"}); err == nil {
+		t.Error("ParseShortcuts expected error for unsupported definition")
+	}
+	if _, err := NewResolvedConfig(&Config{CustomShortcuts: map[string]string{"^btn-(": "x", "btn": "p-4"}}); err == nil {
+		t.Error("NewResolvedConfig expected error for invalid shortcut pattern")
+	}
+}
+
+func TestShortcutDeclarationOrder(t *testing.T) {
+	static := func(name string, props ...string) Rule {
+		return Rule{
+			Static: name,
+			Handler: func(match []string, ctx *RuleContext) *CSSEntry {
+				entries := map[string]string{}
+				for i := 0; i < len(props); i += 2 {
+					entries[props[i]] = props[i+1]
+				}
+				return &CSSEntry{Properties: entries}
+			},
+		}
+	}
+	cfg, err := NewResolvedConfig(&Config{
+		Rules: []Rule{
+			static("border", "border-width", "1px"),
+			static("border-t-0", "border-top-width", "0"),
+			static("p-4", "padding", "1rem"),
+			static("pt-0", "padding-top", "0"),
+			static("p-2", "padding", "0.5rem"),
+		},
+		Extractors:      []Extractor{splitExtractor{}},
+		CustomShortcuts: map[string]string{"frame": "border border-t-0 p-4 pt-0 p-2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A ordem dos membros precisa ser estável: a longhand vem depois da shorthand.
+	want := "border-width: 1px;\n      border-top-width: 0;\n      padding-top: 0;\n      padding: 0.5rem;"
+	for i := 0; i < 20; i++ {
+		css, err := NewGenerator(cfg).Generate(map[string]string{"index.html": "frame"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(css, want) {
+			t.Fatalf("declarations out of order:\n%s", css)
+		}
+	}
+}
+
+func TestShortcutCycle(t *testing.T) {
+	tests := []struct {
+		name      string
+		shortcuts map[string]string
+		token     string
+	}{
+		{"self", map[string]string{"loop": "loop"}, "loop"},
+		{"static into pattern", map[string]string{"btn": "btn-main", "^btn-(\\w+)$": "btn p-$1"}, "btn"},
+		{"growing pattern", map[string]string{"^a-(.+)$": "a-x-$1"}, "a-b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := NewResolvedConfig(&Config{
+				Extractors:      []Extractor{splitExtractor{}},
+				CustomShortcuts: tt.shortcuts,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			g := NewGenerator(cfg)
+			if _, err := g.ParseToken(tt.token); err == nil {
+				t.Errorf("ParseToken(%q) expected error", tt.token)
+			}
+			if _, err := g.Generate(map[string]string{"index.html": tt.token}); err == nil {
+				t.Errorf("Generate(%q) expected error", tt.token)
+			}
+		})
+	}
+}
@@ -2,8 +2,11 @@ package core
 
 import (
 	"fmt"
-	"strings"
+	"math"
+	"regexp"
 	"sort"
+	"strconv"
+	"strings"
 )
 
 // Generate processa um conjunto de tokens e retorna o CSS final.
@@ -13,6 +16,9 @@ func (g *UnoGenerator) Generate(files map[string]string) (string, error) {
 	// Extract tokens from files
 		extractedTokens := make(map[string]bool)
 	for path, content := range files {
+		for _, t := range g.Config.Transformers {
+			content = t.Transform(content, path)
+		}
 		for _, ext := range g.Config.Extractors {
 			for _, token := range ext.Extract(content, path) {
 				extractedTokens[token] = true
@@ -23,8 +29,7 @@ func (g *UnoGenerator) Generate(files map[string]string) (string, error) {
 	for token := range extractedTokens {
 		stringifiedUtils, err := g.ParseToken(token)
 		if err != nil {
-			// TODO: Lidar com o erro, talvez registrar e continuar
-			continue
+			return "", fmt.Errorf("token %q: %w", token, err)
 		}
 		for _, util := range stringifiedUtils {
 			layer := util.Layer
@@ -35,7 +40,26 @@ func (g *UnoGenerator) Generate(files map[string]string) (string, error) {
 		}
 	}
 
-	// TODO: Adicionar Preflights
+	// Adicionar Preflights
+	preflightCSS := make(map[string][]string)
+	preflightCtx := &PreflightContext{Theme: g.Config.Theme}
+	for _, p := range g.Config.Preflights {
+		if p.GetCSS == nil {
+			continue
+		}
+		css := strings.TrimSpace(p.GetCSS(preflightCtx))
+		if css == "" {
+			continue
+		}
+		layer := p.Layer
+		if layer == "" {
+			layer = "preflights"
+		}
+		preflightCSS[layer] = append(preflightCSS[layer], css)
+		if _, ok := layerCSS[layer]; !ok {
+			layerCSS[layer] = nil
+		}
+	}
 
 	// TODO: Ordenar camadas
 	sortedLayers := g.sortLayers(layerCSS)
@@ -43,6 +67,30 @@ func (g *UnoGenerator) Generate(files map[string]string) (string, error) {
 	var finalCSS strings.Builder
 	for _, layer := range sortedLayers {
 		finalCSS.WriteString(fmt.Sprintf("@layer %s {\n", layer))
+
+		for _, css := range preflightCSS[layer] {
+			for _, line := range strings.Split(css, "\n") {
+				finalCSS.WriteString("  " + line + "\n")
+			}
+		}
+
+		// Globals (e.g., @keyframes) are written once per layer, in a stable order
+		globals := make(map[string]bool)
+		for _, util := range layerCSS[layer] {
+			for _, css := range util.Globals {
+				globals[css] = true
+			}
+		}
+		globalKeys := make([]string, 0, len(globals))
+		for css := range globals {
+			globalKeys = append(globalKeys, css)
+		}
+		sort.Strings(globalKeys)
+		for _, css := range globalKeys {
+			for _, line := range strings.Split(css, "\n") {
+				finalCSS.WriteString("  " + line + "\n")
+			}
+		}
 		
 		// Group by parent (e.g., media queries)
 		parentCSS := make(map[string][]*StringifiedUtil)
@@ -54,28 +102,37 @@ func (g *UnoGenerator) Generate(files map[string]string) (string, error) {
 			parentCSS[parent] = append(parentCSS[parent], util)
 		}
 
-		// Sort parents (e.g., media queries) for consistent output
+		// Sort parents (e.g., media queries) so wider breakpoints override narrower ones
 		parentKeys := make([]string, 0, len(parentCSS))
 		for k := range parentCSS {
 			parentKeys = append(parentKeys, k)
 		}
-		sort.Strings(parentKeys)
+		sortParents(parentKeys)
 
 		for _, parent := range parentKeys {
+			var parents []string
 			if parent != "_default" {
-				finalCSS.WriteString(fmt.Sprintf("  %s {\n", parent))
+				parents = strings.Split(parent, ParentSeparator)
+			}
+			for i, p := range parents {
+				finalCSS.WriteString(fmt.Sprintf("%s%s {\n", strings.Repeat("  ", i+1), p))
 			}
 
+			indent := strings.Repeat("  ", len(parents)+1)
+			if len(parents) == 0 {
+				indent = "    "
+			}
+			sortUtils(parentCSS[parent])
 			for _, util := range parentCSS[parent] {
-				finalCSS.WriteString(fmt.Sprintf("    %s {\n", util.Selector))
-				for prop, val := range util.Entries {
-					finalCSS.WriteString(fmt.Sprintf("      %s: %s;\n", prop, val))
+				finalCSS.WriteString(fmt.Sprintf("%s%s {\n", indent, util.Selector))
+				for _, prop := range util.properties() {
+					finalCSS.WriteString(fmt.Sprintf("%s  %s: %s;\n", indent, prop, util.Entries[prop]))
 				}
-				finalCSS.WriteString("    }\n")
+				finalCSS.WriteString(indent + "}\n")
 			}
 
-			if parent != "_default" {
-				finalCSS.WriteString("  }\n")
+			for i := len(parents) - 1; i >= 0; i-- {
+				finalCSS.WriteString(strings.Repeat("  ", i+1) + "}\n")
 			}
 		}
 		finalCSS.WriteString("}\n")
@@ -85,38 +142,58 @@ func (g *UnoGenerator) Generate(files map[string]string) (string, error) {
 }
 
 func (g *UnoGenerator) matchRule(token string) (*Rule, []string) {
-	for _, rule := range g.Config.Rules {
-		if rule.Static != "" {
-			if rule.Static == token {
-				return &rule, []string{token}
-			}
-		} else if rule.Matcher != nil {
-			matches := rule.Matcher.FindStringSubmatch(token)
-			if len(matches) > 0 {
-				return &rule, matches
-			}
+	for i := range g.Config.Rules {
+		if match := g.Config.Rules[i].match(token); match != nil {
+			return &g.Config.Rules[i], match
 		}
 	}
 	return nil, nil
 }
 
+// match retorna os grupos capturados se a regra corresponder ao token.
+func (r *Rule) match(token string) []string {
+	if r.Static != "" {
+		if r.Static == token {
+			return []string{token}
+		}
+		return nil
+	}
+	if r.Matcher != nil {
+		if matches := r.Matcher.FindStringSubmatch(token); len(matches) > 0 {
+			return matches
+		}
+	}
+	return nil
+}
+
 func (g *UnoGenerator) applyVariants(entry *CSSEntry, handlers []*VariantHandler) *CSSEntry {
-	// Apply variants in reverse order
+	// Apply variants in reverse order. A handler may add copies of the entry to
+	// Extra (e.g., one per alternative condition); the outer handlers are
+	// applied to those copies too, and they are returned in the result's Extra.
+	entries := []*CSSEntry{entry}
 	for i := len(handlers) - 1; i >= 0; i-- {
 		handler := handlers[i]
-		entry = handler.Variant.Handler(entry, handler.Match)
+		next := make([]*CSSEntry, 0, len(entries))
+		for _, e := range entries {
+			e = handler.Variant.Handler(e, handler.Match)
+			next = append(next, e)
+			next = append(next, e.Extra...)
+			e.Extra = nil
+		}
+		entries = next
 	}
-	return entry
+	entries[0].Extra = entries[1:]
+	return entries[0]
 }
 
 func (g *UnoGenerator) matchVariants(token string) (string, []*VariantHandler) {
 	var handlers []*VariantHandler
 	current := token
 
+	ctx := &VariantContext{Theme: g.Config.Theme}
 	for {
 		matched := false
 		for _, variant := range g.Config.Variants {
-			ctx := &VariantContext{ /* Add relevant context here if needed */ }
 			if m := variant.Matcher(current, ctx); m != nil {
 				// The matcher should return the remaining token and the match details
 				// For now, let's assume m.Matcher contains the prefix that was matched
@@ -137,23 +214,85 @@ func (g *UnoGenerator) matchVariants(token string) (string, []*VariantHandler) {
 			break
 		}
 	}
+
+	sortHandlers(handlers)
 	return current, handlers
 }
 
+// sortHandlers normaliza as variantes pelo peso: a de maior peso fica
+// primeiro e é aplicada por último, envolvendo as demais. Em caso de empate,
+// a ordem original é mantida. Variantes com Wrap ficam na posição escrita e
+// dividem a lista em trechos normalizados separadamente.
+func sortHandlers(handlers []*VariantHandler) {
+	start := 0
+	for i := 0; i <= len(handlers); i++ {
+		if i < len(handlers) && !handlers[i].Variant.Wrap {
+			continue
+		}
+		run := handlers[start:i]
+		sort.SliceStable(run, func(a, b int) bool {
+			return run[a].Variant.Order > run[b].Variant.Order
+		})
+		start = i + 1
+	}
+}
+
+// dedupeHandlers remove variantes repetidas (mesmo prefixo e valor), mantendo a
+// primeira. Acontece quando um atalho com variante expande para um membro com
+// a mesma variante (`sm:card` com `sm:p-8`, `hover:btn` com `hover:bg-blue-600`).
+// Variantes com Wrap separam trechos independentes: em `hover:*:hover:` os
+// dois hover se referem a elementos diferentes.
+func dedupeHandlers(handlers []*VariantHandler) []*VariantHandler {
+	seen := make(map[VariantMatch]bool, len(handlers))
+	unique := handlers[:0]
+	for _, h := range handlers {
+		if h.Variant.Wrap {
+			seen = make(map[VariantMatch]bool, len(handlers))
+		} else if seen[*h.Match] {
+			continue
+		} else {
+			seen[*h.Match] = true
+		}
+		unique = append(unique, h)
+	}
+	return unique
+}
+
+// variantOrder retorna os pesos das variantes de um token, do maior para o menor.
+func variantOrder(handlers []*VariantHandler) []int {
+	if len(handlers) == 0 {
+		return nil
+	}
+	order := make([]int, len(handlers))
+	for i, h := range handlers {
+		order[i] = h.Variant.Order
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(order)))
+	return order
+}
+
 func (g *UnoGenerator) expandShortcut(token string) (bool, []string, error) {
-	for _, s := range g.Config.Shortcuts {
+	if i, match := g.matchShortcut(token); i >= 0 {
+		return true, g.Config.Shortcuts[i].Expand(match), nil
+	}
+	return false, nil, nil
+}
+
+// matchShortcut retorna o índice do primeiro atalho que corresponde ao token
+// e os grupos capturados, ou -1.
+func (g *UnoGenerator) matchShortcut(token string) (int, []string) {
+	for i, s := range g.Config.Shortcuts {
 		if s.Static != "" {
 			if s.Static == token {
-				return true, s.Expand([]string{token}), nil
+				return i, []string{token}
 			}
 		} else if s.Pattern != nil {
-			matches := s.Pattern.FindStringSubmatch(token)
-			if len(matches) > 0 {
-				return true, s.Expand(matches), nil
+			if matches := s.Pattern.FindStringSubmatch(token); len(matches) > 0 {
+				return i, matches
 			}
 		}
 	}
-	return false, nil, nil
+	return -1, nil
 }
 
 // ParseToken é o coração do pipeline de resolução.
@@ -163,74 +302,214 @@ func (g *UnoGenerator) ParseToken(token string) ([]*StringifiedUtil, error) {
 		return cached, nil
 	}
 
-	// c. Corresponder Variantes
-	remainingToken, variantHandlers := g.matchVariants(token)
-
-	// d. Expandir Atalhos (recursivamente)
-	isShortcut, expandedTokens, err := g.expandShortcut(remainingToken)
+	result, err := g.parseToken(token, "."+EscapeSelector(token), nil, nil)
 	if err != nil {
 		return nil, err
 	}
-	if isShortcut {
+	g.Cache[token] = result
+	return result, nil
+}
+
+// parseToken resolve um token usando selector como seletor base. outer são as
+// variantes herdadas de um atalho (ex.: o `hover:` de `hover:btn`): elas são
+// normalizadas junto com as do próprio token, de modo que os membros de um
+// atalho geram seletores relativos à classe do atalho (`.hover\:btn:hover`,
+// `.btn:hover::placeholder`, `.group:hover .btn`). expanding são os atalhos
+// em expansão até aqui, usados para detectar ciclos.
+func (g *UnoGenerator) parseToken(token, selector string, outer []*VariantHandler, expanding []string) ([]*StringifiedUtil, error) {
+	// c. Corresponder Variantes
+	remainingToken, inner := g.matchVariants(token)
+	variantHandlers := dedupeHandlers(append(append([]*VariantHandler{}, outer...), inner...))
+	sortHandlers(variantHandlers)
+
+	// d. Expandir Atalhos (recursivamente)
+	if i, match := g.matchShortcut(remainingToken); i >= 0 {
+		shortcut := &g.Config.Shortcuts[i]
+		expanding = append(expanding[:len(expanding):len(expanding)], remainingToken)
+		if err := checkShortcutCycle(expanding); err != nil {
+			return nil, err
+		}
 		var result []*StringifiedUtil
-		for _, expandedToken := range expandedTokens {
-			// Recursively parse expanded tokens
-			parsed, err := g.ParseToken(expandedToken)
+		for _, expandedToken := range shortcut.Expand(match) {
+			parsed, err := g.parseToken(expandedToken, selector, variantHandlers, expanding)
 			if err != nil {
 				return nil, err
 			}
-			// Apply variant handlers to recursively parsed tokens
 			for _, util := range parsed {
-				// Create a new CSSEntry from the recursively parsed util
-				entryToApplyVariants := &CSSEntry{
-					Selector: util.Selector,
-					Properties: util.Entries,
-					Layer: util.Layer,
-					Parent: util.Parent,
-				}
-				// Apply variant handlers from the original token
-				finalEntry := g.applyVariants(entryToApplyVariants, variantHandlers)
-				result = append(result, &StringifiedUtil{
-					Selector: finalEntry.Selector,
-					Entries:  finalEntry.Properties,
-					Layer:    util.Layer, // Layer should come from the original rule of the expanded token
-					Parent:   finalEntry.Parent,
-				})
+				util.Layer = shortcut.layer()
+				// Atalhos vêm depois de todas as regras da mesma camada.
+				util.RuleIndex = len(g.Config.Rules) + i
 			}
+			result = append(result, parsed...)
 		}
-		g.Cache[token] = result
-		return result, nil
+		return mergeUtils(result), nil
 	}
 
 	// e. Corresponder Regras
-	rule, match := g.matchRule(remainingToken)
-	if rule == nil {
+	// Uma regra cujo handler retorna nil não se aplica ao token; nesse caso
+	// a próxima regra que corresponder é tentada.
+	ctx := &RuleContext{
+		RawSelector:     token,
+		CurrentSelector: remainingToken,
+		Theme:           g.Config.Theme,
+		VariantHandlers: variantHandlers,
+	}
+	var rule *Rule
+	var ruleIndex int
+	var cssEntry *CSSEntry
+	for i := range g.Config.Rules {
+		match := g.Config.Rules[i].match(remainingToken)
+		if match == nil {
+			continue
+		}
+		// f. Gerar CSS a partir da regra
+		if cssEntry = g.Config.Rules[i].Handler(match, ctx); cssEntry != nil {
+			rule = &g.Config.Rules[i]
+			ruleIndex = i
+			break
+		}
+	}
+	if cssEntry == nil {
 		// Token não correspondeu a nada
-		g.Cache[token] = nil
 		return nil, nil
 	}
+	var result []*StringifiedUtil
+	ruleEntries := append([]*CSSEntry{cssEntry}, cssEntry.Extra...)
+	cssEntry.Extra = nil
+	for _, entry := range ruleEntries {
+		if entry.Selector == "" {
+			entry.Selector = selector
+		}
 
-	// f. Gerar CSS a partir da regra
-	ctx := &RuleContext{ RawSelector: token, CurrentSelector: remainingToken } // Contexto simplificado por enquanto
-	cssEntry := rule.Handler(match, ctx)
+		// g. Aplicar Variantes
+		finalEntry := g.applyVariants(entry, variantHandlers)
 
-	if cssEntry == nil {
-		return nil, nil
+		// h. Serializar
+		for _, e := range append([]*CSSEntry{finalEntry}, finalEntry.Extra...) {
+			layer := e.Layer
+			if rule.Meta != nil && rule.Meta.Layer != "" {
+				layer = rule.Meta.Layer // Layer should come from the original rule
+			}
+			result = append(result, &StringifiedUtil{
+				Selector:     e.Selector + e.Suffix,
+				Entries:      e.Properties,
+				Layer:        layer,
+				Parent:       e.Parent,
+				Globals:      e.Globals,
+				RuleIndex:    ruleIndex,
+				VariantOrder: variantOrder(variantHandlers),
+			})
+		}
 	}
+	// Uma variante pode levar uma entrada extra para o parent da principal
+	// (`md:container`: o `@media (min-width: 768px)` da regra se funde ao da
+	// variante); as duas viram uma só regra.
+	return mergeUtils(result), nil
+}
 
-	// g. Aplicar Variantes
-	finalEntry := g.applyVariants(cssEntry, variantHandlers)
+// maxShortcutDepth limita o aninhamento de atalhos, para padrões que geram um
+// token novo a cada expansão (ex.: `^a-(.+)$` -> `a-x-$1`).
+const maxShortcutDepth = 32
 
-	// h. Serializar e armazenar no cache
-	util := &StringifiedUtil{
-		Selector: finalEntry.Selector,
-		Entries:  finalEntry.Properties,
-		Layer:    rule.Meta.Layer, // Layer should come from the original rule
-		Parent:   finalEntry.Parent,
+// checkShortcutCycle retorna um erro se o último atalho de expanding já está
+// sendo expandido (`loop` -> `loop`) ou se o aninhamento passou do limite.
+func checkShortcutCycle(expanding []string) error {
+	last := expanding[len(expanding)-1]
+	for _, token := range expanding[:len(expanding)-1] {
+		if token == last {
+			return fmt.Errorf("shortcut cycle: %s", strings.Join(expanding, " -> "))
+		}
 	}
-	g.Cache[token] = []*StringifiedUtil{util}
+	if len(expanding) > maxShortcutDepth {
+		return fmt.Errorf("shortcut %q nested more than %d levels deep", expanding[0], maxShortcutDepth)
+	}
+	return nil
+}
 
-	return g.Cache[token], nil
+// mergeUtils junta os utilitários de um token ou atalho que compartilham
+// seletor e parent em um só, na ordem da primeira ocorrência. As declarações
+// seguem a ordem dos membros; uma declaração repetida vai para a posição do
+// último membro que a define, para que prevaleça sobre as anteriores (ex.:
+// `p-4 pt-0 p-2` termina com padding depois de padding-top).
+func mergeUtils(utils []*StringifiedUtil) []*StringifiedUtil {
+	var merged []*StringifiedUtil
+	index := make(map[string]*StringifiedUtil)
+	for _, util := range utils {
+		key := util.Parent + "\x00" + util.Selector
+		target, ok := index[key]
+		if !ok {
+			target = &StringifiedUtil{
+				Selector:     util.Selector,
+				Entries:      make(map[string]string, len(util.Entries)),
+				Layer:        util.Layer,
+				Parent:       util.Parent,
+				RuleIndex:    util.RuleIndex,
+				VariantOrder: util.VariantOrder,
+			}
+			index[key] = target
+			merged = append(merged, target)
+		}
+		for _, prop := range util.properties() {
+			if _, exists := target.Entries[prop]; exists {
+				target.EntryOrder = removeString(target.EntryOrder, prop)
+			}
+			target.Entries[prop] = util.Entries[prop]
+			target.EntryOrder = append(target.EntryOrder, prop)
+		}
+		target.Globals = append(target.Globals, util.Globals...)
+	}
+	return merged
+}
+
+// properties retorna as propriedades na ordem de escrita: EntryOrder e, em
+// seguida, as demais em ordem alfabética.
+func (u *StringifiedUtil) properties() []string {
+	props := make([]string, 0, len(u.Entries))
+	seen := make(map[string]bool, len(u.EntryOrder))
+	for _, prop := range u.EntryOrder {
+		if _, ok := u.Entries[prop]; ok && !seen[prop] {
+			props = append(props, prop)
+			seen[prop] = true
+		}
+	}
+	rest := make([]string, 0, len(u.Entries)-len(props))
+	for prop := range u.Entries {
+		if !seen[prop] {
+			rest = append(rest, prop)
+		}
+	}
+	sort.Strings(rest)
+	return append(props, rest...)
+}
+
+func removeString(list []string, s string) []string {
+	for i, item := range list {
+		if item == s {
+			return append(list[:i], list[i+1:]...)
+		}
+	}
+	return list
+}
+
+// ParentSeparator separa parents aninhados em CSSEntry.Parent
+// (ex.: "@media (min-width: 640px) $$ @container (min-width: 24rem)").
+const ParentSeparator = " $$ "
+
+// NestParent envolve o parent atual de uma entrada com um parent externo. Um
+// parent que já faz parte do aninhamento não é repetido.
+func NestParent(outer, inner string) string {
+	if inner == "" {
+		return outer
+	}
+	if outer == "" {
+		return inner
+	}
+	for _, p := range strings.Split(inner, ParentSeparator) {
+		if p == outer {
+			return inner
+		}
+	}
+	return outer + ParentSeparator + inner
 }
 
 
@@ -264,4 +543,136 @@ func (g *UnoGenerator) sortLayers(layers map[string][]*StringifiedUtil) []string
 			return layerA < layerB
 		})
 	return keys
-}
\ No newline at end of file
+}
+
+// sortParents ordena os parents na ordem da cascata: utilitários sem parent
+// primeiro, depois condições de largura máxima (da maior para a menor), depois
+// de largura mínima (da menor para a maior) e por fim os demais, em ordem
+// alfabética. Parents aninhados são comparados nível a nível, de fora para
+// dentro; com os níveis em comum empatados, o menos aninhado vem primeiro.
+func sortParents(parents []string) {
+	sort.SliceStable(parents, func(i, j int) bool {
+		return compareParents(parents[i], parents[j]) < 0
+	})
+}
+
+func compareParents(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == "_default" || b == "_default" {
+		if a == "_default" {
+			return -1
+		}
+		return 1
+	}
+	levelsA := strings.Split(a, ParentSeparator)
+	levelsB := strings.Split(b, ParentSeparator)
+	for i := 0; i < len(levelsA) && i < len(levelsB); i++ {
+		if c := compareParentLevel(levelsA[i], levelsB[i]); c != 0 {
+			return c
+		}
+	}
+	if len(levelsA) != len(levelsB) {
+		// Um parent repetido é omitido no aninhamento (`md:container` tem o
+		// 768px da variante e o da regra como um só nível), então o último
+		// nível de largura do mais curto ainda vale para o nível seguinte do
+		// mais longo.
+		shorter, longer, sign := levelsA, levelsB, 1
+		if len(levelsA) > len(levelsB) {
+			shorter, longer, sign = levelsB, levelsA, -1
+		}
+		last, next := shorter[len(shorter)-1], longer[len(shorter)]
+		if groupLast, _ := parentWeight(last); groupLast != 3 {
+			if groupNext, _ := parentWeight(next); groupNext == groupLast {
+				if c := compareParentLevel(last, next); c != 0 {
+					return sign * c
+				}
+			}
+		}
+		return -sign
+	}
+	return strings.Compare(a, b)
+}
+
+func compareParentLevel(a, b string) int {
+	groupA, sizeA := parentWeight(a)
+	groupB, sizeB := parentWeight(b)
+	switch {
+	case groupA != groupB:
+		return groupA - groupB
+	case sizeA == sizeB:
+		return strings.Compare(a, b)
+	case (sizeA > sizeB) == (groupA == 1):
+		return -1
+	default:
+		return 1
+	}
+}
+
+var (
+	minWidthRE = regexp.MustCompile(`\(min-width:\s*(?:calc\()?(-?[\d.]+[a-z]*)`)
+	maxWidthRE = regexp.MustCompile(`\(max-width:\s*(?:calc\()?(-?[\d.]+[a-z]*)`)
+)
+
+// parentWeight retorna o grupo e o tamanho em pixels de um único nível de parent.
+func parentWeight(parent string) (int, float64) {
+	if m := maxWidthRE.FindStringSubmatch(parent); m != nil && !strings.Contains(parent, "min-width") {
+		return 1, SizeInPixels(m[1])
+	}
+	if m := minWidthRE.FindStringSubmatch(parent); m != nil {
+		if strings.Contains(parent, "not all and") {
+			return 1, SizeInPixels(m[1])
+		}
+		return 2, SizeInPixels(m[1])
+	}
+	return 3, 0
+}
+
+// SizeInPixels converte um tamanho CSS em px, rem ou em para pixels
+// (1rem = 16px), para fins de ordenação. Valores desconhecidos resultam em +Inf.
+func SizeInPixels(size string) float64 {
+	size = strings.TrimSpace(size)
+	unit := 1.0
+	switch {
+	case strings.HasSuffix(size, "px"):
+		size = strings.TrimSuffix(size, "px")
+	case strings.HasSuffix(size, "rem"), strings.HasSuffix(size, "em"):
+		size = strings.TrimSuffix(strings.TrimSuffix(size, "rem"), "em")
+		unit = 16
+	}
+	f, err := strconv.ParseFloat(size, 64)
+	if err != nil {
+		return math.Inf(1)
+	}
+	return f * unit
+}
+
+// sortUtils ordena os utilitários de um mesmo parent para a cascata: primeiro
+// pelas variantes (comparando os pesos do maior para o menor, como uma máscara
+// de bits, de modo que `focus:` vem depois de `hover:`), depois pela ordem das
+// regras e por fim pelo seletor.
+func sortUtils(utils []*StringifiedUtil) {
+	sort.SliceStable(utils, func(i, j int) bool {
+		a, b := utils[i], utils[j]
+		if c := compareOrder(a.VariantOrder, b.VariantOrder); c != 0 {
+			return c < 0
+		}
+		if a.RuleIndex != b.RuleIndex {
+			return a.RuleIndex < b.RuleIndex
+		}
+		return a.Selector < b.Selector
+	})
+}
+
+func compareOrder(a, b []int) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return len(a) - len(b)
+}
@@ -0,0 +1,157 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RuleDefinition descreve uma regra sem código Go. Uma regra estática usa Name;
+// uma dinâmica usa Pattern, e os valores de Properties podem referenciar os
+// grupos capturados com $0..$9. Com Theme, $1 é resolvido na seção do tema
+// indicada (ou como valor arbitrário, `[...]`); se a chave não existir, a
+// regra não se aplica e a próxima é tentada.
+//
+//	{Name: "block", Properties: {"display": "block"}}
+//	{Pattern: "^m-(.+)$", Theme: "spacing", Properties: {"margin": "$1"}}
+type RuleDefinition struct {
+	Name       string            `json:"name,omitempty" yaml:"name,omitempty"`
+	Pattern    string            `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	Theme      string            `json:"theme,omitempty" yaml:"theme,omitempty"`
+	Properties map[string]string `json:"properties" yaml:"properties"`
+	Selector   string            `json:"selector,omitempty" yaml:"selector,omitempty"` // Seletor fixo (ex.: "html"); padrão: a classe do token
+	Layer      string            `json:"layer,omitempty" yaml:"layer,omitempty"`
+}
+
+// RuleSet é o formato dos arquivos de regras: definições com uma camada padrão.
+type RuleSet struct {
+	Layer string           `json:"layer,omitempty" yaml:"layer,omitempty"`
+	Rules []RuleDefinition `json:"rules" yaml:"rules"`
+}
+
+// placeholderRE encontra referências a grupos capturados ($0..$9).
+var placeholderRE = regexp.MustCompile(`\$\d`)
+
+// CompileRule converte uma definição declarativa em uma Rule.
+func CompileRule(def RuleDefinition) (Rule, error) {
+	if (def.Name == "") == (def.Pattern == "") {
+		return Rule{}, fmt.Errorf("rule definition must have exactly one of name or pattern")
+	}
+	if len(def.Properties) == 0 {
+		return Rule{}, fmt.Errorf("rule %q has no properties", def.Name+def.Pattern)
+	}
+
+	rule := Rule{Static: def.Name}
+	if def.Layer != "" {
+		rule.Meta = &RuleMeta{Layer: def.Layer}
+	}
+	if def.Pattern != "" {
+		re, err := regexp.Compile(def.Pattern)
+		if err != nil {
+			return Rule{}, fmt.Errorf("rule %q: %w", def.Pattern, err)
+		}
+		rule.Matcher = re
+	}
+
+	properties, selector, section := def.Properties, def.Selector, def.Theme
+	rule.Handler = func(match []string, ctx *RuleContext) *CSSEntry {
+		if section != "" {
+			if len(match) < 2 {
+				return nil
+			}
+			value, ok := ArbitraryValue(match[1])
+			if !ok {
+				if value, ok = ThemeValue(ctx.Theme, section, match[1]); !ok {
+					return nil
+				}
+			}
+			match = append([]string{match[0], value}, match[2:]...)
+		}
+
+		props := make(map[string]string, len(properties))
+		for prop, template := range properties {
+			props[prop] = placeholderRE.ReplaceAllStringFunc(template, func(ref string) string {
+				if i, _ := strconv.Atoi(ref[1:]); i < len(match) {
+					return match[i]
+				}
+				return ref
+			})
+		}
+		return &CSSEntry{Properties: props, Selector: selector}
+	}
+	return rule, nil
+}
+
+// ArbitraryValue extrai o conteúdo de um valor arbitrário (`[...]`),
+// trocando `_` por espaços como no Tailwind (`[1px_solid]` -> "1px solid").
+func ArbitraryValue(s string) (string, bool) {
+	if len(s) < 3 || s[0] != '[' || s[len(s)-1] != ']' {
+		return "", false
+	}
+	return strings.ReplaceAll(s[1:len(s)-1], "_", " "), true
+}
+
+// CompileRules converte uma lista de definições, na mesma ordem.
+func CompileRules(defs []RuleDefinition) ([]Rule, error) {
+	rules := make([]Rule, 0, len(defs))
+	for _, def := range defs {
+		rule, err := CompileRule(def)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Compile converte as definições do conjunto, usando a camada do conjunto
+// para as definições sem camada própria.
+func (s RuleSet) Compile() ([]Rule, error) {
+	defs := make([]RuleDefinition, len(s.Rules))
+	for i, def := range s.Rules {
+		if def.Layer == "" {
+			def.Layer = s.Layer
+		}
+		defs[i] = def
+	}
+	return CompileRules(defs)
+}
+
+// LoadRulesJSON compila um RuleSet em JSON.
+func LoadRulesJSON(data []byte) ([]Rule, error) {
+	var set RuleSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("parsing rules: %w", err)
+	}
+	return set.Compile()
+}
+
+// LoadRulesYAML compila um RuleSet em YAML.
+func LoadRulesYAML(data []byte) ([]Rule, error) {
+	var set RuleSet
+	if err := yaml.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("parsing rules: %w", err)
+	}
+	return set.Compile()
+}
+
+// LoadRulesFile lê e compila um arquivo de regras .json, .yaml ou .yml.
+func LoadRulesFile(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return LoadRulesJSON(data)
+	case ".yaml", ".yml":
+		return LoadRulesYAML(data)
+	}
+	return nil, fmt.Errorf("unsupported rules file %q", path)
+}
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// EscapeSelector escapa um nome de classe para uso em um seletor CSS,
+// seguindo o algoritmo de CSS.escape().
+func EscapeSelector(name string) string {
+	var sb strings.Builder
+	for i, r := range name {
+		switch {
+		case r == 0:
+			sb.WriteString("�")
+		case r >= '0' && r <= '9' && (i == 0 || (i == 1 && name[0] == '-')):
+			// Dígitos no início do identificador precisam ser escapados como código
+			sb.WriteString(`\3`)
+			sb.WriteRune(r)
+			sb.WriteByte(' ')
+		case r == '-' && i == 0 && utf8.RuneCountInString(name) == 1:
+			sb.WriteString(`\-`)
+		case r >= utf8.RuneSelf,
+			r == '-', r == '_',
+			r >= '0' && r <= '9',
+			r >= 'a' && r <= 'z',
+			r >= 'A' && r <= 'Z':
+			sb.WriteRune(r)
+		default:
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ShortcutFromString cria o atalho estático `name` a partir de uma lista de
+// tokens separados por espaços. Os tokens podem ter variantes e grupos de
+// variantes:
+//
+//	ShortcutFromString("btn", "py-2 px-4 font-bold hover:(bg-blue-600 text-white)")
+func ShortcutFromString(name, expansion string) Shortcut {
+	tokens := splitShortcut(expansion)
+	return Shortcut{
+		Static: name,
+		Expand: func(match []string) []string {
+			return append([]string(nil), tokens...)
+		},
+	}
+}
+
+// ShortcutFromPattern cria um atalho dinâmico a partir de uma expressão
+// regular e de um template em que $1, ${1} ou ${nome} são substituídos pelos
+// grupos capturados:
+//
+//	ShortcutFromPattern(`^btn-(\w+)$`, "bg-$1-500 hover:bg-$1-600 text-white")
+func ShortcutFromPattern(pattern, template string) (Shortcut, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return Shortcut{}, fmt.Errorf("shortcut %q: %w", pattern, err)
+	}
+	return Shortcut{
+		Pattern: re,
+		Expand: func(match []string) []string {
+			indexes := re.FindStringSubmatchIndex(match[0])
+			if indexes == nil {
+				return nil
+			}
+			return splitShortcut(string(re.ExpandString(nil, template, match[0], indexes)))
+		},
+	}, nil
+}
+
+// ShortcutsFromMap cria atalhos a partir de um mapa nome -> expansão. Chaves
+// iniciadas por "^" são expressões regulares (veja ShortcutFromPattern). Os
+// atalhos estáticos vêm primeiro; dentro de cada grupo, a ordem é alfabética.
+func ShortcutsFromMap(shortcuts map[string]string) ([]Shortcut, error) {
+	var names, patterns []string
+	for key := range shortcuts {
+		if strings.HasPrefix(key, "^") {
+			patterns = append(patterns, key)
+		} else {
+			names = append(names, key)
+		}
+	}
+	sort.Strings(names)
+	sort.Strings(patterns)
+
+	result := make([]Shortcut, 0, len(shortcuts))
+	for _, name := range names {
+		result = append(result, ShortcutFromString(name, shortcuts[name]))
+	}
+	for _, pattern := range patterns {
+		s, err := ShortcutFromPattern(pattern, shortcuts[pattern])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+// ParseShortcuts converte uma lista que mistura mapas de expansões
+// (map[string]string, veja ShortcutsFromMap), Shortcut e []Shortcut,
+// preservando a ordem da lista.
+func ParseShortcuts(defs ...interface{}) ([]Shortcut, error) {
+	var result []Shortcut
+	for _, def := range defs {
+		switch d := def.(type) {
+		case map[string]string:
+			shortcuts, err := ShortcutsFromMap(d)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, shortcuts...)
+		case Shortcut:
+			result = append(result, d)
+		case []Shortcut:
+			result = append(result, d...)
+		default:
+			return nil, fmt.Errorf("unsupported shortcut definition %T", def)
+		}
+	}
+	return result, nil
+}
+
+// splitShortcut expande os grupos de variantes e separa os tokens.
+func splitShortcut(expansion string) []string {
+	return strings.Fields(ExpandVariantGroup(expansion))
+}
@@ -0,0 +1,36 @@
+package core
+
+import "strings"
+
+// ThemeValue procura uma chave em uma seção do tema. Listas são unidas com
+// vírgulas (ex.: fontFamily) e mapas usam a chave "DEFAULT"; uma chave vazia
+// também resolve para "DEFAULT".
+func ThemeValue(theme map[string]interface{}, section, key string) (string, bool) {
+	m, _ := theme[section].(map[string]interface{})
+	if key == "" {
+		key = "DEFAULT"
+	}
+	return ThemeString(m[key])
+}
+
+// ThemeString converte um valor do tema em string: listas são unidas com
+// vírgulas e mapas usam a chave "DEFAULT".
+func ThemeString(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case []string:
+		return strings.Join(v, ", "), len(v) > 0
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				items = append(items, s)
+			}
+		}
+		return strings.Join(items, ", "), len(items) > 0
+	case map[string]interface{}:
+		return ThemeString(v["DEFAULT"])
+	}
+	return "", false
+}
@@ -16,6 +16,7 @@ type ResolvedConfig struct {
 	Shortcuts     []Shortcut
 	Preflights    []Preflight
 	Extractors    []Extractor
+	Transformers  []Transformer
 	Layers        map[string]int
 	Postprocess   []Postprocessor
 }
@@ -35,18 +36,35 @@ type RuleMeta struct {
 }
 
 // Variant define como manipular prefixos como `hover:` ou `md:`.
+//
+// Order é o peso da variante. As variantes de um token são normalizadas por
+// ele (a de maior peso é aplicada por último, envolvendo as demais), de modo
+// que `hover:focus:` e `focus:hover:` geram o mesmo seletor; na saída, dentro
+// de um mesmo parent, utilitários com variantes de maior peso vêm depois.
+//
+// Variantes com Wrap envolvem o seletor (ex.: `*:`, `group-hover:`) e não são
+// reordenadas: `hover:*:` e `*:hover:` selecionam elementos diferentes. A
+// normalização acontece apenas entre elas.
 type Variant struct {
-	Matcher  func(token string, ctx *VariantContext) *VariantMatch
-	Handler  func(entry *CSSEntry, match *VariantMatch) *CSSEntry
+	Matcher   func(token string, ctx *VariantContext) *VariantMatch
+	Handler   func(entry *CSSEntry, match *VariantMatch) *CSSEntry
 	MultiPass bool // Se a variante pode ser aplicada múltiplas vezes
+	Order     int
+	Wrap      bool
 }
 
 // CSSEntry representa uma unidade de CSS gerada.
+//
+// Se Selector estiver vazio, o gerador usa a classe do token original
+// escapada (ex.: `.hover\:text-red-500\/50`).
 type CSSEntry struct {
 	Properties map[string]string
 	Selector   string
-	Parent     string // Para media queries, etc.
+	Suffix     string // Anexado após as variantes (ex.: "::placeholder")
+	Parent     string // Para media queries, etc. Parents aninhados são unidos por ParentSeparator
 	Layer      string
+	Globals    []string    // CSS global emitido uma única vez quando o utilitário é usado (ex.: @keyframes)
+	Extra      []*CSSEntry // Entradas adicionais da regra ou da variante (ex.: o max-width de `container` por breakpoint)
 }
 
 // RuleContext fornece contexto para os handlers de regras.
@@ -60,26 +78,66 @@ type RuleContext struct {
 // Outras structs a serem definidas
 // StringifiedUtil representa uma regra de CSS processada e pronta para ser escrita.
 type StringifiedUtil struct {
-	Selector string
-	Entries  map[string]string
-	Layer    string
-	Parent   string // For media queries, e.g., "@media (min-width: 640px)"
+	Selector     string
+	Entries      map[string]string
+	EntryOrder   []string // Ordem das declarações (ex.: membros de um atalho); vazia: ordem alfabética
+	Layer        string
+	Parent       string // For media queries, e.g., "@media (min-width: 640px)"
+	Globals      []string
+	RuleIndex    int   // Posição da regra em Config.Rules (atalhos: len(Rules) + posição em Shortcuts), para ordenar a saída
+	VariantOrder []int // Pesos das variantes aplicadas, do maior para o menor
 }
+// Shortcut expande um token em uma lista de outros tokens. As declarações dos
+// tokens expandidos são mescladas sob a classe do atalho e emitidas na camada
+// Meta.Layer (DefaultShortcutLayer se vazia), de modo que um utilitário comum
+// possa sobrescrevê-las.
 type Shortcut struct {
 	Pattern *regexp.Regexp
 	Static  string
 	Expand  func(match []string) []string
+	Meta    *RuleMeta
+}
+
+// DefaultShortcutLayer é a camada dos atalhos sem Meta.Layer.
+const DefaultShortcutLayer = "components"
+
+// layer retorna a camada em que a saída do atalho é emitida.
+func (s *Shortcut) layer() string {
+	if s.Meta != nil && s.Meta.Layer != "" {
+		return s.Meta.Layer
+	}
+	return DefaultShortcutLayer
+}
+
+// Preflight é um bloco de CSS global (resets, valores padrão de variáveis)
+// emitido no início da sua camada, antes dos utilitários.
+type Preflight struct {
+	Layer  string // Camada do preflight; "preflights" se vazio
+	GetCSS func(ctx *PreflightContext) string // Preflights sem GetCSS são ignorados
 }
-type Preflight struct{}
+
+// PreflightContext fornece contexto para a geração de preflights.
+type PreflightContext struct {
+	Theme map[string]interface{}
+}
+
 type Extractor interface {
 	Extract(code string, path string) []string
 }
+
+// Transformer reescreve o código-fonte antes da extração de tokens
+// (ex.: expansão de grupos de variantes).
+type Transformer interface {
+	Transform(code string, path string) string
+}
 type Postprocessor interface{}
-type VariantContext struct{}
+type VariantContext struct {
+	Theme map[string]interface{}
+}
 
 type VariantMatch struct {
 	Matcher string
-	// Add other fields as needed for variant context, e.g., value for `sm:`
+	Value   string // Valor resolvido pelo matcher (ex.: a condição de `@lg/sidebar:`)
 }
 
 type VariantHandler struct {
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"regexp"
+	"strings"
+)
+
+// variantGroupDepth limita as passadas de expansão de grupos aninhados.
+const variantGroupDepth = 8
+
+// ExpandVariantGroup expande a sintaxe de grupos de variantes do UnoCSS:
+// `hover:(bg-blue-500 text-white)` vira `hover:bg-blue-500 hover:text-white` e
+// `text-(red-500 lg)` vira `text-red-500 text-lg`. Grupos aninhados são
+// expandidos de dentro para fora e `~` representa o próprio prefixo
+// (`border-(~ red-500)` vira `border border-red-500`). Sem separadores
+// explícitos são usados ":" e "-".
+func ExpandVariantGroup(code string, separators ...string) string {
+	if !strings.Contains(code, "(") {
+		return code
+	}
+	re := variantGroupRE(separators)
+	for i := 0; i < variantGroupDepth; i++ {
+		expanded := re.ReplaceAllStringFunc(code, func(group string) string {
+			m := re.FindStringSubmatch(group)
+			prefix, sep, body := m[1], m[2], m[3]
+			items := strings.Fields(body)
+			for j, item := range items {
+				if item == "~" {
+					items[j] = prefix
+				} else {
+					items[j] = prefix + sep + item
+				}
+			}
+			return strings.Join(items, " ")
+		})
+		if expanded == code {
+			break
+		}
+		code = expanded
+	}
+	return code
+}
+
+// defaultVariantGroupRE usa os separadores padrão ":" e "-".
+var defaultVariantGroupRE = compileVariantGroupRE([]string{":", "-"})
+
+func variantGroupRE(separators []string) *regexp.Regexp {
+	if len(separators) == 0 {
+		return defaultVariantGroupRE
+	}
+	return compileVariantGroupRE(separators)
+}
+
+func compileVariantGroupRE(separators []string) *regexp.Regexp {
+	quoted := make([]string, len(separators))
+	for i, sep := range separators {
+		quoted[i] = regexp.QuoteMeta(sep)
+	}
+	// prefixo (variantes e utilitários, incluindo seletores arbitrários), separador e corpo do grupo
+	return regexp.MustCompile(`((?:[!@<~\w+:_-]|\[&?>?:?[^\s\]]*\])+?)(` + strings.Join(quoted, "|") +
+		`)\(((?:[~!<>\w\s:/\\,%#.$?-]|\[[^\]]*?\])+?)\)`)
+}
@@ -0,0 +1,116 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// VariantFromTemplate cria a variante `<name>:` a partir de um template CSS,
+// sem precisar escrever o Matcher e o Handler. "&" representa o seletor do
+// utilitário e at-rules envolvendo o template viram parents aninhados:
+//
+//	"&:is(.theme-x *)"                   -> .theme-x\:flex:is(.theme-x *)
+//	"@media (hover: hover) { &:hover }"  -> @media (hover: hover) { .x:hover }
+//	"@media print"                       -> @media print { .x }
+//
+// Um template sem "&" é anexado ao seletor (":hover" equivale a "&:hover").
+// Listas de seletores são agrupadas em `:is(...)`, para que as variantes
+// aplicadas depois valham para todos os itens:
+//
+//	"&:hover, &:focus"                   -> :is(.hocus\:flex:hover, .hocus\:flex:focus)
+func VariantFromTemplate(name, template string) (Variant, error) {
+	parents, selector, err := parseVariantTemplate(template)
+	if err != nil {
+		return Variant{}, fmt.Errorf("variant %q: %w", name, err)
+	}
+	prefix := name + ":"
+	parent := strings.Join(parents, ParentSeparator)
+	list := isSelectorList(selector)
+
+	return Variant{
+		Matcher: func(token string, ctx *VariantContext) *VariantMatch {
+			if strings.HasPrefix(token, prefix) {
+				return &VariantMatch{Matcher: prefix}
+			}
+			return nil
+		},
+		Handler: func(entry *CSSEntry, match *VariantMatch) *CSSEntry {
+			entry.Selector = strings.ReplaceAll(selector, "&", entry.Selector)
+			if list {
+				entry.Selector = ":is(" + entry.Selector + ")"
+			}
+			entry.Parent = NestParent(parent, entry.Parent)
+			return entry
+		},
+	}, nil
+}
+
+// parseVariantTemplate separa as at-rules externas do template do seletor.
+func parseVariantTemplate(template string) ([]string, string, error) {
+	var parents []string
+	t := strings.TrimSpace(template)
+	for strings.HasPrefix(t, "@") {
+		open := strings.Index(t, "{")
+		if open < 0 {
+			// at-rule sem bloco: o seletor fica inalterado
+			parents = append(parents, t)
+			t = "&"
+			break
+		}
+		if !strings.HasSuffix(t, "}") {
+			return nil, "", fmt.Errorf("unbalanced braces in template %q", template)
+		}
+		parents = append(parents, strings.TrimSpace(t[:open]))
+		t = strings.TrimSpace(t[open+1 : len(t)-1])
+	}
+	if strings.ContainsAny(t, "{}") {
+		return nil, "", fmt.Errorf("unexpected braces in template %q", template)
+	}
+	if t == "" {
+		t = "&"
+	}
+	if !strings.Contains(t, "&") {
+		t = "&" + t
+	}
+	return parents, t, nil
+}
+
+// templateVariants converte o mapa Config.CustomVariants em variantes,
+// em ordem alfabética de nome. Retorna o erro do primeiro template inválido.
+func templateVariants(templates map[string]string) ([]Variant, error) {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	variants := make([]Variant, 0, len(names))
+	for _, name := range names {
+		v, err := VariantFromTemplate(name, templates[name])
+		if err != nil {
+			return nil, err
+		}
+		variants = append(variants, v)
+	}
+	return variants, nil
+}
+
+// isSelectorList indica se o seletor tem vírgulas fora de parênteses e
+// colchetes (ex.: "&:hover, &:focus", mas não "&:where(.a, .b)").
+func isSelectorList(selector string) bool {
+	depth := 0
+	for _, c := range selector {
+		switch c {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
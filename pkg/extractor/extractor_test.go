@@ -113,6 +113,51 @@ func TestTemplExtractor(t *testing.T) {
 			code:     `<div :className="'baz qux'"></div>`,
 			expected: []string{"baz", "qux"},
 		},
+		{
+			name:     "quotes of the other kind inside the value",
+			code:     `<div class="font-['Inter'] p-2"></div>`,
+			expected: []string{"font-['Inter']", "p-2"},
+		},
+		{
+			name:     "bound class expression",
+			code:     "<div :class=\"isActive ? 'bg-blue-500 text-white' : `bg-gray-100`\"></div>",
+			expected: []string{"bg-blue-500", "text-white", "bg-gray-100"},
+		},
+		{
+			name:     "templ expression attribute",
+			code:     `<div class={ "flex gap-2", templ.KV("hidden", !visible) }></div>`,
+			expected: []string{"flex", "gap-2", "hidden"},
+		},
+		{
+			name:     "templ.Classes with raw strings and comments",
+			code:     "<button class={ templ.Classes(\n\t\"btn\", // primary {\n\t`hover:bg-blue-600\n\ttext-white`,\n\tbutton(),\n) }>Save</button>",
+			expected: []string{"btn", "hover:bg-blue-600", "text-white"},
+		},
+		{
+			name:     "templ.Classes in Go code",
+			code:     "func classes(active bool) templ.CSSClasses {\n\treturn templ.Classes(\"rounded\", templ.KV(\"ring-2\", active))\n}",
+			expected: []string{"rounded", "ring-2"},
+		},
+		{
+			name:     "escaped quotes in Go strings",
+			code:     `<div class={ "content-[\"hi\"] m-1" }></div>`,
+			expected: []string{`content-["hi"]`, "m-1"},
+		},
+		{
+			name:     "css components are skipped",
+			code:     "css card() {\n\tfont-family: \"Inter\";\n\tclass: \"no\";\n}\n\ntempl Card() {\n\t<div class={ card(), \"p-4\" }></div>\n}",
+			expected: []string{"p-4"},
+		},
+		{
+			name:     "attributes that only end in class",
+			code:     `<div data-class="no" class="yes"></div>`,
+			expected: []string{"yes"},
+		},
+		{
+			name:     "text outside class contexts",
+			code:     `<p>{ "not-a-class" } class names</p>`,
+			expected: []string{},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,246 @@
+package extractor
+
+import (
+	"strconv"
+	"strings"
+)
+
+// TemplExtractor implements core.Extractor for HTML and templ (.templ) files.
+//
+// It extracts tokens from every class context:
+//   - static attributes: class="...", className='...'
+//   - bound attributes, whose value is an expression: :class="'a ' + b"
+//   - templ expression attributes: class={ "a", templ.KV("b", cond) }
+//   - templ.Classes(...) and templ.KV(...) calls anywhere in the file
+//
+// Inside expressions, every Go string literal (interpreted or raw) is split
+// into tokens; identifiers, comments and other code are ignored. The bodies of
+// templ css components hold plain CSS declarations and are skipped.
+type TemplExtractor struct{}
+
+// classAttributes lists the attribute names that hold classes. Longer names
+// come first so that "className" is not matched as "class".
+var classAttributes = []string{":className", ":class", "className", "class"}
+
+// classCalls lists the templ helpers whose arguments are classes.
+var classCalls = []string{"templ.Classes(", "templ.KV("}
+
+func (e *TemplExtractor) Extract(code string, path string) []string {
+	tokens := []string{}
+	for i := 0; i < len(code); {
+		if end, ok := skipCSSComponent(code, i); ok {
+			i = end
+			continue
+		}
+		if found, end := classAttribute(code, i); end > i {
+			tokens = append(tokens, found...)
+			i = end
+			continue
+		}
+		if found, end := classCall(code, i); end > i {
+			tokens = append(tokens, found...)
+			i = end
+			continue
+		}
+		i++
+	}
+	return tokens
+}
+
+// classAttribute parses a class attribute starting at i and returns its
+// tokens and the position after its value, or i if there is none.
+func classAttribute(code string, i int) ([]string, int) {
+	if i > 0 && !isSpace(code[i-1]) {
+		return nil, i
+	}
+	name := ""
+	for _, attr := range classAttributes {
+		if strings.HasPrefix(code[i:], attr) {
+			name = attr
+			break
+		}
+	}
+	if name == "" {
+		return nil, i
+	}
+	j := skipSpaces(code, i+len(name))
+	if j >= len(code) || code[j] != '=' {
+		return nil, i
+	}
+	j = skipSpaces(code, j+1)
+	if j >= len(code) {
+		return nil, i
+	}
+
+	bound := strings.HasPrefix(name, ":")
+	switch quote := code[j]; quote {
+	case '"', '\'':
+		end := strings.IndexByte(code[j+1:], quote)
+		if end < 0 {
+			return nil, i
+		}
+		value := code[j+1 : j+1+end]
+		if bound {
+			return stringLiterals(value, true), j + end + 2
+		}
+		return strings.Fields(value), j + end + 2
+	case '{':
+		end := matchBracket(code, j)
+		if end < 0 {
+			return nil, i
+		}
+		return stringLiterals(code[j+1:end-1], false), end
+	default:
+		// Unquoted attribute value: class=flex
+		end := j
+		for end < len(code) && !isSpace(code[end]) && code[end] != '>' {
+			end++
+		}
+		return strings.Fields(code[j:end]), end
+	}
+}
+
+// classCall parses a templ.Classes or templ.KV call starting at i and returns
+// the tokens of its arguments and the position after the call, or i if there
+// is none.
+func classCall(code string, i int) ([]string, int) {
+	for _, call := range classCalls {
+		if !strings.HasPrefix(code[i:], call) {
+			continue
+		}
+		open := i + len(call) - 1
+		end := matchBracket(code, open)
+		if end < 0 {
+			return nil, i
+		}
+		return stringLiterals(code[open+1:end-1], false), end
+	}
+	return nil, i
+}
+
+// skipCSSComponent returns the position after a top-level templ css component
+// (`css name() { ... }`) starting at i.
+func skipCSSComponent(code string, i int) (int, bool) {
+	if (i > 0 && code[i-1] != '\n') || !strings.HasPrefix(code[i:], "css ") {
+		return i, false
+	}
+	open := strings.IndexByte(code[i:], '{')
+	if open < 0 {
+		return i, false
+	}
+	end := matchBracket(code, i+open)
+	if end < 0 {
+		return i, false
+	}
+	return end, true
+}
+
+// matchBracket returns the position after the bracket that closes the one at
+// open, skipping string literals and comments, or -1 if it is unbalanced.
+func matchBracket(code string, open int) int {
+	closing := map[byte]byte{'{': '}', '(': ')', '[': ']'}[code[open]]
+	depth := 0
+	for i := open; i < len(code); {
+		switch c := code[i]; {
+		case c == '"' || c == '\'' || c == '`':
+			_, end := scanLiteral(code, i)
+			if end < 0 {
+				return -1
+			}
+			i = end
+			continue
+		case strings.HasPrefix(code[i:], "//"):
+			end := strings.IndexByte(code[i:], '\n')
+			if end < 0 {
+				return -1
+			}
+			i += end
+			continue
+		case strings.HasPrefix(code[i:], "/*"):
+			end := strings.Index(code[i+2:], "*/")
+			if end < 0 {
+				return -1
+			}
+			i += end + 4
+			continue
+		case c == code[open]:
+			depth++
+		case c == closing:
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+		i++
+	}
+	return -1
+}
+
+// stringLiterals returns the tokens of every string literal in a Go (or, with
+// singleQuotes, JavaScript) expression.
+func stringLiterals(expr string, singleQuotes bool) []string {
+	var tokens []string
+	for i := 0; i < len(expr); {
+		switch c := expr[i]; {
+		case c == '"' || c == '`' || c == '\'':
+			value, end := scanLiteral(expr, i)
+			if end < 0 {
+				return tokens
+			}
+			if c != '\'' || singleQuotes {
+				tokens = append(tokens, strings.Fields(value)...)
+			}
+			i = end
+		case strings.HasPrefix(expr[i:], "//"):
+			end := strings.IndexByte(expr[i:], '\n')
+			if end < 0 {
+				return tokens
+			}
+			i += end
+		case strings.HasPrefix(expr[i:], "/*"):
+			end := strings.Index(expr[i+2:], "*/")
+			if end < 0 {
+				return tokens
+			}
+			i += end + 4
+		default:
+			i++
+		}
+	}
+	return tokens
+}
+
+// scanLiteral reads the quoted literal starting at i and returns its value and
+// the position after it, or -1 if it is not terminated. Backquoted literals
+// are raw; the others may contain backslash escapes.
+func scanLiteral(code string, i int) (string, int) {
+	quote := code[i]
+	for j := i + 1; j < len(code); j++ {
+		switch code[j] {
+		case '\\':
+			if quote != '`' {
+				j++
+			}
+		case quote:
+			literal := code[i : j+1]
+			if quote == '"' {
+				if value, err := strconv.Unquote(literal); err == nil {
+					return value, j + 1
+				}
+			}
+			return literal[1 : len(literal)-1], j + 1
+		}
+	}
+	return "", -1
+}
+
+func skipSpaces(code string, i int) int {
+	for i < len(code) && isSpace(code[i]) {
+		i++
+	}
+	return i
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
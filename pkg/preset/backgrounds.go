@@ -0,0 +1,209 @@
+package preset
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/su3h7am/gocss/pkg/core"
+)
+
+// gradientDirections mapeia os sufixos de direção para os lados do gradiente.
+var gradientDirections = map[string]string{
+	"t":  "top",
+	"tr": "top right",
+	"r":  "right",
+	"br": "bottom right",
+	"b":  "bottom",
+	"bl": "bottom left",
+	"l":  "left",
+	"tl": "top left",
+}
+
+// gradientImageRE identifica valores arbitrários que são imagens de fundo.
+var gradientImageRE = regexp.MustCompile(`^(url|image|image-set|cross-fade|element|(repeating-)?(linear|radial|conic)-gradient)\(`)
+
+func getBackgroundRules(opts *windOptions) []core.Rule {
+	rules := []core.Rule{
+		// Linear gradients: bg-gradient-to-r, bg-linear-to-tl, bg-linear-45, bg-linear-[to_right,red,blue]
+		{
+			Matcher: regexp.MustCompile(`^bg-(?:gradient|linear)-to-(tl|tr|bl|br|[trbl])$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				return gradientEntry("linear-gradient", "to "+gradientDirections[match[1]])
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		{
+			Matcher: regexp.MustCompile(`^(-?)bg-linear-(\d+|\[.+\])$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				if v, ok := arbitraryValue(match[2]); ok {
+					return &core.CSSEntry{Properties: map[string]string{"background-image": "linear-gradient(" + v + ")"}}
+				}
+				return gradientEntry("linear-gradient", match[1]+match[2]+"deg")
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		// Radial and conic gradients: bg-radial, bg-radial-[at_25%_25%], bg-conic-180
+		{
+			Matcher: regexp.MustCompile(`^bg-radial(?:-(\[.+\]))?$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				position, _ := arbitraryValue(match[1])
+				return gradientEntry("radial-gradient", position)
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		{
+			Matcher: regexp.MustCompile(`^(-?)bg-conic(?:-(\d+|\[.+\]))?$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				if match[2] == "" {
+					return gradientEntry("conic-gradient", "")
+				}
+				if v, ok := arbitraryValue(match[2]); ok {
+					return &core.CSSEntry{Properties: map[string]string{"background-image": "conic-gradient(" + v + ")"}}
+				}
+				return gradientEntry("conic-gradient", "from "+match[1]+match[2]+"deg")
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+
+		// Color stops: from-red-500, via-white/50, to-[#123], from-10%, to-[80px]
+		{
+			Matcher: regexp.MustCompile(`^(from|via|to)-(.+)$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				stop, body := match[1], match[2]
+				if position, ok := gradientPosition(body); ok {
+					return &core.CSSEntry{Properties: map[string]string{"--tw-gradient-" + stop + "-position": position}}
+				}
+
+				c, modifier, ok := parseThemeColor(body, ctx.Theme)
+				if !ok {
+					return nil
+				}
+				if modifier != "" {
+					alpha, ok := parseOpacity(modifier)
+					if !ok {
+						return nil
+					}
+					c = c.WithAlpha(alpha)
+				}
+				color := c.CSS(opts.colorFormat)
+				transparent := c.WithAlpha(0).CSS(opts.colorFormat)
+
+				switch stop {
+				case "from":
+					return &core.CSSEntry{Properties: map[string]string{
+						"--tw-gradient-from":  color + " var(--tw-gradient-from-position)",
+						"--tw-gradient-to":    transparent + " var(--tw-gradient-to-position)",
+						"--tw-gradient-stops": "var(--tw-gradient-from), var(--tw-gradient-to)",
+					}}
+				case "via":
+					return &core.CSSEntry{Properties: map[string]string{
+						"--tw-gradient-to":    transparent + " var(--tw-gradient-to-position)",
+						"--tw-gradient-stops": "var(--tw-gradient-from), " + color + " var(--tw-gradient-via-position), var(--tw-gradient-to)",
+					}}
+				}
+				return &core.CSSEntry{Properties: map[string]string{
+					"--tw-gradient-to": color + " var(--tw-gradient-to-position)",
+				}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+
+		// Arbitrary backgrounds: bg-[url(/img.png)], bg-[length:200px_100px], bg-[position:center_top], bg-[center_top_1rem].
+		// Cores arbitrárias (bg-[#fff]) ficam para a regra de cores.
+		{
+			Matcher: regexp.MustCompile(`^bg-(\[.+\])$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				v, _ := arbitraryValue(match[1])
+				if hint, value, ok := strings.Cut(v, ":"); ok {
+					property := map[string]string{
+						"image":    "background-image",
+						"url":      "background-image",
+						"length":   "background-size",
+						"size":     "background-size",
+						"position": "background-position",
+					}[hint]
+					if property != "" {
+						if hint == "url" {
+							value = "url(" + value + ")"
+						}
+						return &core.CSSEntry{Properties: map[string]string{property: value}}
+					}
+				}
+				if gradientImageRE.MatchString(v) {
+					return &core.CSSEntry{Properties: map[string]string{"background-image": v}}
+				}
+				if isPosition(v) {
+					return &core.CSSEntry{Properties: map[string]string{"background-position": v}}
+				}
+				return nil
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		staticRule("bg-none", map[string]string{"background-image": "none"}),
+		staticRule("bg-clip-text", map[string]string{"-webkit-background-clip": "text", "background-clip": "text"}),
+	}
+
+	rules = append(rules, valueRules("bg", "background-attachment", "fixed", "local", "scroll")...)
+	rules = append(rules, mappedRules("bg-clip", "background-clip", map[string]string{
+		"border": "border-box", "padding": "padding-box", "content": "content-box",
+	})...)
+	rules = append(rules, mappedRules("bg-origin", "background-origin", map[string]string{
+		"border": "border-box", "padding": "padding-box", "content": "content-box",
+	})...)
+	rules = append(rules, mappedRules("bg", "background-repeat", map[string]string{
+		"repeat": "repeat", "no-repeat": "no-repeat", "repeat-x": "repeat-x", "repeat-y": "repeat-y",
+		"repeat-round": "round", "repeat-space": "space",
+	})...)
+	rules = append(rules, valueRules("bg", "background-size", "auto", "cover", "contain")...)
+	rules = append(rules, mappedRules("bg", "background-position", map[string]string{
+		"bottom": "bottom", "center": "center", "left": "left", "left-bottom": "left bottom", "left-top": "left top",
+		"right": "right", "right-bottom": "right bottom", "right-top": "right top", "top": "top",
+	})...)
+	rules = append(rules, valueRules("bg-blend", "background-blend-mode",
+		"normal", "multiply", "screen", "overlay", "darken", "lighten", "color-dodge", "color-burn",
+		"hard-light", "soft-light", "difference", "exclusion", "hue", "saturation", "color", "luminosity",
+	)...)
+	return rules
+}
+
+// gradientEntry gera o background-image de um gradiente com os color stops.
+func gradientEntry(function, args string) *core.CSSEntry {
+	if args != "" {
+		args += ", "
+	}
+	return &core.CSSEntry{Properties: map[string]string{
+		"background-image": fmt.Sprintf("%s(%svar(--tw-gradient-stops))", function, args),
+	}}
+}
+
+// gradientPosition reconhece posições de color stop: "10%" ou comprimentos arbitrários.
+func gradientPosition(body string) (string, bool) {
+	if strings.HasSuffix(body, "%") && numberRE.MatchString(strings.TrimSuffix(body, "%")) {
+		return body, true
+	}
+	if v, ok := arbitraryValue(body); ok && isLength(v) {
+		return strings.TrimPrefix(v, "length:"), true
+	}
+	return "", false
+}
+
+// positionKeywords são as palavras-chave aceitas em background-position.
+var positionKeywords = map[string]bool{"center": true, "top": true, "right": true, "bottom": true, "left": true}
+
+// isPosition indica se o valor é uma lista de posições, formada só por
+// palavras-chave e comprimentos (ex.: "center top 1rem", "0 0, 50% 50%").
+func isPosition(v string) bool {
+	for _, position := range splitTopLevel(v, ',') {
+		parts := strings.Fields(position)
+		if len(parts) == 0 {
+			return false
+		}
+		for _, part := range parts {
+			if !positionKeywords[part] && (strings.HasPrefix(part, "length:") || !isLength(part)) {
+				return false
+			}
+		}
+	}
+	return true
+}
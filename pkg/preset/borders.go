@@ -0,0 +1,230 @@
+package preset
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/su3h7am/gocss/pkg/core"
+)
+
+// divideSuffix seleciona os irmãos entre os quais os divisores são desenhados.
+const divideSuffix = " > :not([hidden]) ~ :not([hidden])"
+
+// Composição de box-shadow compartilhada por rings e sombras.
+const (
+	ringOffsetShadow = "var(--tw-ring-inset) 0 0 0 var(--tw-ring-offset-width) var(--tw-ring-offset-color)"
+	ringBoxShadow    = "var(--tw-ring-offset-shadow), var(--tw-ring-shadow), var(--tw-shadow, 0 0 #0000)"
+	shadowBoxShadow  = "var(--tw-ring-offset-shadow, 0 0 #0000), var(--tw-ring-shadow, 0 0 #0000), var(--tw-shadow)"
+)
+
+// borderSides mapeia o sufixo de lado para as propriedades lógicas ou físicas
+// afetadas (ex.: "x" -> left e right).
+var borderSides = map[string][]string{
+	"":  {""},
+	"x": {"-left", "-right"},
+	"y": {"-top", "-bottom"},
+	"t": {"-top"},
+	"r": {"-right"},
+	"b": {"-bottom"},
+	"l": {"-left"},
+	"s": {"-inline-start"},
+	"e": {"-inline-end"},
+}
+
+// radiusCorners mapeia lados e cantos para as propriedades de border-radius.
+var radiusCorners = map[string][]string{
+	"":   {"border-radius"},
+	"t":  {"border-top-left-radius", "border-top-right-radius"},
+	"r":  {"border-top-right-radius", "border-bottom-right-radius"},
+	"b":  {"border-bottom-right-radius", "border-bottom-left-radius"},
+	"l":  {"border-top-left-radius", "border-bottom-left-radius"},
+	"s":  {"border-start-start-radius", "border-end-start-radius"},
+	"e":  {"border-start-end-radius", "border-end-end-radius"},
+	"tl": {"border-top-left-radius"},
+	"tr": {"border-top-right-radius"},
+	"br": {"border-bottom-right-radius"},
+	"bl": {"border-bottom-left-radius"},
+	"ss": {"border-start-start-radius"},
+	"se": {"border-start-end-radius"},
+	"ee": {"border-end-end-radius"},
+	"es": {"border-end-start-radius"},
+}
+
+func getBorderRules(opts *windOptions) []core.Rule {
+	rules := []core.Rule{
+		// Border width: border, border-2, border-x, border-t-4, border-[3px]
+		{
+			Matcher: regexp.MustCompile(`^border(?:-([xytrblse]))?(?:-(.+))?$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				width, ok := widthValue(ctx.Theme, "borderWidth", match[2])
+				if !ok {
+					return nil
+				}
+				props := map[string]string{}
+				for _, side := range borderSides[match[1]] {
+					props["border"+side+"-width"] = width
+				}
+				return &core.CSSEntry{Properties: props}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		// Border side color: border-t-red-500, border-x-black/50
+		{
+			Matcher: regexp.MustCompile(`^border-([xytrblse])-(.+)$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				color, ok := resolveColor(match[2], ctx.Theme, opts.colorFormat)
+				if !ok {
+					return nil
+				}
+				props := map[string]string{}
+				for _, side := range borderSides[match[1]] {
+					props["border"+side+"-color"] = color
+				}
+				return &core.CSSEntry{Properties: props}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		// Border radius: rounded, rounded-lg, rounded-t, rounded-tl-xl, rounded-[12px]
+		{
+			Matcher: regexp.MustCompile(`^rounded(?:-(tl|tr|br|bl|ss|se|ee|es|[trblse]))?(?:-(.+))?$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				radius, ok := arbitraryValue(match[2])
+				if !ok {
+					if radius, ok = lookupTheme(ctx.Theme, "borderRadius", match[2]); !ok {
+						return nil
+					}
+				}
+				props := map[string]string{}
+				for _, p := range radiusCorners[match[1]] {
+					props[p] = radius
+				}
+				return &core.CSSEntry{Properties: props}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+
+		// Ring width: ring, ring-2, ring-[3px]
+		{
+			Matcher: regexp.MustCompile(`^ring(?:-(.+))?$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				width, ok := widthValue(ctx.Theme, "ringWidth", match[1])
+				if !ok {
+					return nil
+				}
+				return &core.CSSEntry{Properties: map[string]string{
+					"--tw-ring-offset-shadow": ringOffsetShadow,
+					"--tw-ring-shadow":        fmt.Sprintf("var(--tw-ring-inset) 0 0 0 calc(%s + var(--tw-ring-offset-width)) var(--tw-ring-color)", width),
+					"box-shadow":              ringBoxShadow,
+				}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		staticRule("ring-inset", map[string]string{"--tw-ring-inset": "inset"}),
+		// Ring offset width: ring-offset-2
+		{
+			Matcher: regexp.MustCompile(`^ring-offset-(.+)$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				width, ok := widthValue(ctx.Theme, "ringOffsetWidth", match[1])
+				if !ok {
+					return nil
+				}
+				return &core.CSSEntry{Properties: map[string]string{"--tw-ring-offset-width": width}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+
+		// Outline
+		staticRule("outline-none", map[string]string{"outline": "2px solid transparent", "outline-offset": "2px"}),
+		staticRule("outline", map[string]string{"outline-style": "solid"}),
+		{
+			Matcher: regexp.MustCompile(`^outline-(.+)$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				width, ok := widthValue(ctx.Theme, "outlineWidth", match[1])
+				if !ok {
+					return nil
+				}
+				return &core.CSSEntry{Properties: map[string]string{"outline-width": width}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		{
+			Matcher: regexp.MustCompile(`^(-?)outline-offset-(.+)$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				offset, ok := widthValue(ctx.Theme, "outlineOffset", match[2])
+				if !ok {
+					return nil
+				}
+				if match[1] == "-" {
+					offset = negate(offset)
+				}
+				return &core.CSSEntry{Properties: map[string]string{"outline-offset": offset}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+
+		// Divide width: divide-x, divide-y-2, divide-x-reverse
+		{
+			Matcher: regexp.MustCompile(`^divide-([xy])(?:-(.+))?$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				axis := match[1]
+				reverse := "var(--tw-divide-" + axis + "-reverse)"
+				if match[2] == "reverse" {
+					return &core.CSSEntry{
+						Properties: map[string]string{"--tw-divide-" + axis + "-reverse": "1"},
+						Suffix:     divideSuffix,
+					}
+				}
+				width, ok := widthValue(ctx.Theme, "borderWidth", match[2])
+				if !ok {
+					return nil
+				}
+				start, end := "border-left-width", "border-right-width"
+				if axis == "y" {
+					start, end = "border-top-width", "border-bottom-width"
+				}
+				return &core.CSSEntry{
+					Properties: map[string]string{
+						"--tw-divide-" + axis + "-reverse": "0",
+						end:                                fmt.Sprintf("calc(%s * %s)", width, reverse),
+						start:                              fmt.Sprintf("calc(%s * calc(1 - %s))", width, reverse),
+					},
+					Suffix: divideSuffix,
+				}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+	}
+
+	borderStyles := []string{"solid", "dashed", "dotted", "double", "hidden", "none"}
+	rules = append(rules, valueRules("border", "border-style", borderStyles...)...)
+	rules = append(rules, valueRules("outline", "outline-style", "dashed", "dotted", "double")...)
+	for _, style := range borderStyles {
+		rules = append(rules, core.Rule{
+			Static: "divide-" + style,
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				return &core.CSSEntry{Properties: map[string]string{"border-style": style}, Suffix: divideSuffix}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		})
+	}
+	return rules
+}
+
+// widthValue resolve uma largura em uma seção do tema ("" usa DEFAULT), números
+// em pixels ("3" -> "3px") ou comprimentos arbitrários ("[3px]").
+func widthValue(theme map[string]interface{}, section, key string) (string, bool) {
+	if v, ok := arbitraryValue(key); ok {
+		if !isLength(v) {
+			return "", false
+		}
+		return strings.TrimPrefix(v, "length:"), true
+	}
+	if v, ok := lookupTheme(theme, section, key); ok {
+		return v, true
+	}
+	if numberRE.MatchString(key) && !strings.HasPrefix(key, "-") {
+		return key + "px", true
+	}
+	return "", false
+}
@@ -0,0 +1,112 @@
+package preset
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/su3h7am/gocss/pkg/colors"
+	"github.com/su3h7am/gocss/pkg/core"
+)
+
+// colorUtility descreve um utilitário que recebe uma cor do tema.
+type colorUtility struct {
+	prefix     string
+	suffix     string
+	properties []string
+	extra      map[string]string // Propriedades fixas geradas junto com a cor
+}
+
+var colorUtilities = []colorUtility{
+	{prefix: "text", properties: []string{"color"}},
+	{prefix: "bg", properties: []string{"background-color"}},
+	{prefix: "border", properties: []string{"border-color"}},
+	{prefix: "ring", properties: []string{"--tw-ring-color"}},
+	{prefix: "ring-offset", properties: []string{"--tw-ring-offset-color"}},
+	{prefix: "outline", properties: []string{"outline-color"}},
+	{prefix: "fill", properties: []string{"fill"}},
+	{prefix: "stroke", properties: []string{"stroke"}},
+	{prefix: "decoration", properties: []string{"text-decoration-color"}},
+	{prefix: "accent", properties: []string{"accent-color"}},
+	{prefix: "caret", properties: []string{"caret-color"}},
+	{prefix: "placeholder", suffix: "::placeholder", properties: []string{"color"}},
+	{prefix: "shadow", properties: []string{"--tw-shadow-color"}, extra: map[string]string{"--tw-shadow": "var(--tw-shadow-colored)"}},
+	{prefix: "divide", suffix: divideSuffix, properties: []string{"border-color"}},
+}
+
+func getColorRules(opts *windOptions) []core.Rule {
+	rules := make([]core.Rule, 0, len(colorUtilities))
+	for _, u := range colorUtilities {
+		rules = append(rules, colorRule(u, opts.colorFormat))
+	}
+	return rules
+}
+
+// colorRule cria a regra `<prefixo>-<cor>[/<opacidade>]` para um utilitário de cor.
+func colorRule(u colorUtility, format colors.Format) core.Rule {
+	return core.Rule{
+		Matcher: regexp.MustCompile(fmt.Sprintf(`^%s-(.+)$`, regexp.QuoteMeta(u.prefix))),
+		Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+			color, ok := resolveColor(match[1], ctx.Theme, format)
+			if !ok {
+				return nil
+			}
+			props := copyProps(u.extra)
+			for _, p := range u.properties {
+				props[p] = color
+			}
+			return &core.CSSEntry{Properties: props, Suffix: u.suffix}
+		},
+		Meta: &core.RuleMeta{Layer: "utilities"},
+	}
+}
+
+// resolveColor resolve o corpo de um utilitário de cor ("red-500", "red-500/50",
+// "[#123456]/[0.35]") para um valor CSS. Retorna false se o corpo não for uma cor.
+func resolveColor(body string, theme map[string]interface{}, format colors.Format) (string, bool) {
+	c, modifier, ok := parseThemeColor(body, theme)
+	if !ok {
+		return "", false
+	}
+	if modifier != "" {
+		alpha, ok := parseOpacity(modifier)
+		if !ok || c.Raw == "inherit" {
+			return "", false
+		}
+		c = c.WithAlpha(alpha)
+	}
+	return c.CSS(format), true
+}
+
+// parseThemeColor lê a cor de um corpo de utilitário, seja ela do tema ou arbitrária,
+// e devolve o modificador de opacidade ainda não aplicado.
+func parseThemeColor(body string, theme map[string]interface{}) (colors.Color, string, bool) {
+	name, modifier := splitModifier(body)
+
+	value, ok := arbitraryValue(name)
+	if ok {
+		value = strings.TrimPrefix(value, "color:")
+	} else if value, ok = lookupColor(theme, name); !ok {
+		return colors.Color{}, "", false
+	}
+	c, ok := colors.Parse(value)
+	return c, modifier, ok
+}
+
+// parseOpacity converte um modificador de opacidade ("50", "[0.35]", "[35%]") para 0..1.
+// Valores fora do intervalo (0..100, ou 0..1 entre colchetes) são rejeitados.
+func parseOpacity(modifier string) (float64, bool) {
+	scale := 100.0
+	if v, ok := arbitraryValue(modifier); ok {
+		modifier, scale = v, 1
+		if strings.HasSuffix(v, "%") {
+			modifier, scale = strings.TrimSuffix(v, "%"), 100
+		}
+	}
+	f, err := strconv.ParseFloat(modifier, 64)
+	if err != nil || f < 0 || f > scale {
+		return 0, false
+	}
+	return f / scale, true
+}
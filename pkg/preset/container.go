@@ -0,0 +1,103 @@
+package preset
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/su3h7am/gocss/pkg/core"
+)
+
+// containerVariantRE reconhece variantes de container query: @md:, @[400px]:, @lg/sidebar:.
+var containerVariantRE = regexp.MustCompile(`^@(\[[^\]]+\]|[\w-]+)(?:/([\w-]+))?:`)
+
+func getContainerRules() []core.Rule {
+	return []core.Rule{
+		// container: largura total e max-width em cada breakpoint do tema. A seção
+		// "container" do tema aceita `center` (bool) e `padding` (string ou mapa
+		// por breakpoint, com "DEFAULT").
+		{
+			Static: "container",
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				options := themeSection(ctx.Theme, "container")
+				entry := &core.CSSEntry{Properties: map[string]string{"width": "100%"}}
+				if center, _ := options["center"].(bool); center {
+					entry.Properties["margin-left"] = "auto"
+					entry.Properties["margin-right"] = "auto"
+				}
+				setContainerPadding(entry.Properties, options["padding"], "DEFAULT")
+
+				for _, bp := range themeBreakpoints(ctx.Theme, "screens") {
+					props := map[string]string{"max-width": bp.size}
+					setContainerPadding(props, options["padding"], bp.name)
+					entry.Extra = append(entry.Extra, &core.CSSEntry{
+						Properties: props,
+						Parent:     fmt.Sprintf("@media (min-width: %s)", bp.size),
+					})
+				}
+				return entry
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		// Container query markers: @container, @container/sidebar, @container-normal
+		{
+			Matcher: regexp.MustCompile(`^@container(?:-(normal|size))?(?:/([\w-]+))?$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				containerType := "inline-size"
+				if match[1] != "" {
+					containerType = match[1]
+				}
+				props := map[string]string{"container-type": containerType}
+				if match[2] != "" {
+					props["container-name"] = match[2]
+				}
+				return &core.CSSEntry{Properties: props}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+	}
+}
+
+// setContainerPadding aplica o padding horizontal do container para um breakpoint.
+func setContainerPadding(props map[string]string, padding interface{}, key string) {
+	var value string
+	switch p := padding.(type) {
+	case string:
+		if key != "DEFAULT" {
+			return
+		}
+		value = p
+	case map[string]interface{}:
+		value, _ = p[key].(string)
+	}
+	if value != "" {
+		props["padding-left"] = value
+		props["padding-right"] = value
+	}
+}
+
+func getContainerVariants() []core.Variant {
+	return []core.Variant{
+		// Container queries: @md:, @[400px]:, @lg/sidebar:
+		{
+			Matcher: func(token string, ctx *core.VariantContext) *core.VariantMatch {
+				m := containerVariantRE.FindStringSubmatch(token)
+				if m == nil {
+					return nil
+				}
+				size, ok := arbitraryValue(m[1])
+				if !ok {
+					if size, ok = lookupTheme(ctx.Theme, "containers", m[1]); !ok {
+						return nil
+					}
+				}
+				query := "@container "
+				if m[2] != "" {
+					query += m[2] + " "
+				}
+				return &core.VariantMatch{Matcher: m[0], Value: query + "(min-width: " + size + ")"}
+			},
+			Handler: parentVariant,
+			Order:   orderContainer,
+		},
+	}
+}
@@ -0,0 +1,225 @@
+package preset
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/su3h7am/gocss/pkg/colors"
+	"github.com/su3h7am/gocss/pkg/core"
+)
+
+// filterFunc descreve uma função de filtro CSS (blur, brightness, ...).
+type filterFunc struct {
+	name     string
+	negative bool                                                          // Aceita valores negativos (ex.: -hue-rotate-15)
+	value    func(theme map[string]interface{}, key string) (string, bool) // Argumento da função para a chave
+}
+
+var filterFuncs = []filterFunc{
+	{name: "blur", value: func(theme map[string]interface{}, key string) (string, bool) {
+		if v, ok := arbitraryValue(key); ok {
+			return v, true
+		}
+		return lookupTheme(theme, "blur", key)
+	}},
+	{name: "brightness", value: ratioValue},
+	{name: "contrast", value: ratioValue},
+	{name: "grayscale", value: percentValue},
+	{name: "hue-rotate", negative: true, value: degreeValue},
+	{name: "invert", value: percentValue},
+	{name: "saturate", value: ratioValue},
+	{name: "sepia", value: percentValue},
+}
+
+func getEffectRules() []core.Rule {
+	filter := composeVars(filterVars)
+	backdrop := composeVars(backdropVars)
+
+	rules := []core.Rule{
+		// Box shadow: shadow, shadow-md, shadow-none, shadow-[0_0_2px_black]
+		{
+			Matcher: regexp.MustCompile(`^shadow(?:-(.+))?$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				v, ok := arbitraryValue(match[1])
+				if ok {
+					// shadow-[#000] é uma cor de sombra, tratada pela regra de cores
+					if _, isColor := colors.Parse(strings.TrimPrefix(v, "color:")); isColor {
+						return nil
+					}
+				} else if v, ok = lookupTheme(ctx.Theme, "boxShadow", match[1]); !ok {
+					return nil
+				}
+				if v == "none" {
+					return &core.CSSEntry{Properties: map[string]string{
+						"--tw-shadow":         "0 0 #0000",
+						"--tw-shadow-colored": "0 0 #0000",
+						"box-shadow":          shadowBoxShadow,
+					}}
+				}
+				return &core.CSSEntry{Properties: map[string]string{
+					"--tw-shadow":         v,
+					"--tw-shadow-colored": coloredShadow(v),
+					"box-shadow":          shadowBoxShadow,
+				}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		// Opacity: opacity-50, opacity-[.67]
+		{
+			Matcher: regexp.MustCompile(`^opacity-(.+)$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				v, ok := ratioValue(ctx.Theme, match[1])
+				if !ok {
+					return nil
+				}
+				return &core.CSSEntry{Properties: map[string]string{"opacity": v}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		// Drop shadow: drop-shadow, drop-shadow-lg, drop-shadow-[0_35px_35px_rgba(0,0,0,0.25)]
+		{
+			Matcher: regexp.MustCompile(`^drop-shadow(?:-(.+))?$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				var shadows []string
+				if v, ok := arbitraryValue(match[1]); ok {
+					shadows = []string{v}
+				} else {
+					key := match[1]
+					if key == "" {
+						key = "DEFAULT"
+					}
+					if shadows, ok = lookupThemeList(ctx.Theme, "dropShadow", key); !ok {
+						return nil
+					}
+				}
+				for i, s := range shadows {
+					shadows[i] = "drop-shadow(" + s + ")"
+				}
+				return &core.CSSEntry{Properties: map[string]string{
+					"--tw-drop-shadow": strings.Join(shadows, " "),
+					"filter":           filter,
+				}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		// Backdrop opacity: backdrop-opacity-50
+		filterRule("backdrop-", filterFunc{name: "opacity", value: ratioValue}, "backdrop-filter", backdrop),
+
+		staticRule("filter", map[string]string{"filter": filter}),
+		staticRule("filter-none", map[string]string{"filter": "none"}),
+		staticRule("backdrop-filter", map[string]string{"-webkit-backdrop-filter": backdrop, "backdrop-filter": backdrop}),
+		staticRule("backdrop-filter-none", map[string]string{"-webkit-backdrop-filter": "none", "backdrop-filter": "none"}),
+	}
+
+	for _, fn := range filterFuncs {
+		rules = append(rules, filterRule("", fn, "filter", filter))
+		rules = append(rules, filterRule("backdrop-", fn, "backdrop-filter", backdrop))
+	}
+
+	rules = append(rules, valueRules("mix-blend", "mix-blend-mode",
+		"normal", "multiply", "screen", "overlay", "darken", "lighten", "color-dodge", "color-burn",
+		"hard-light", "soft-light", "difference", "exclusion", "hue", "saturation", "color", "luminosity",
+		"plus-darker", "plus-lighter",
+	)...)
+	return rules
+}
+
+// filterRule cria a regra `[-]<prefix><função>[-<valor>]`, que define apenas a
+// variável da função e repete a composição completa do filtro.
+func filterRule(prefix string, fn filterFunc, property, composition string) core.Rule {
+	variable := "--tw-" + prefix + fn.name
+	return core.Rule{
+		Matcher: regexp.MustCompile(fmt.Sprintf(`^(-?)%s%s(?:-(.+))?$`, prefix, fn.name)),
+		Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+			if match[1] == "-" && !fn.negative {
+				return nil
+			}
+			v, ok := fn.value(ctx.Theme, match[2])
+			if !ok {
+				return nil
+			}
+			if match[1] == "-" {
+				v = negate(v)
+			}
+			props := map[string]string{
+				variable: fmt.Sprintf("%s(%s)", fn.name, v),
+				property: composition,
+			}
+			if property == "backdrop-filter" {
+				props["-webkit-backdrop-filter"] = composition
+			}
+			return &core.CSSEntry{Properties: props}
+		},
+		Meta: &core.RuleMeta{Layer: "utilities"},
+	}
+}
+
+// ratioValue converte porcentagens da escala ("50") em razões ("0.5").
+func ratioValue(theme map[string]interface{}, key string) (string, bool) {
+	if v, ok := arbitraryValue(key); ok {
+		return v, true
+	}
+	if !numberRE.MatchString(key) || strings.HasPrefix(key, "-") {
+		return "", false
+	}
+	n, _ := strconv.ParseFloat(key, 64)
+	return formatNumber(n / 100), true
+}
+
+// percentValue trata filtros liga/desliga: "" -> 100%, "0" -> 0, "50" -> 50%.
+func percentValue(theme map[string]interface{}, key string) (string, bool) {
+	if v, ok := arbitraryValue(key); ok {
+		return v, true
+	}
+	switch {
+	case key == "":
+		return "100%", true
+	case key == "0":
+		return "0", true
+	case numberRE.MatchString(key) && !strings.HasPrefix(key, "-"):
+		return key + "%", true
+	}
+	return "", false
+}
+
+// degreeValue converte números em graus ("15" -> "15deg").
+func degreeValue(theme map[string]interface{}, key string) (string, bool) {
+	if v, ok := arbitraryValue(key); ok {
+		return v, true
+	}
+	if !numberRE.MatchString(key) || strings.HasPrefix(key, "-") {
+		return "", false
+	}
+	return key + "deg", true
+}
+
+// coloredShadow troca a cor de cada camada da sombra por var(--tw-shadow-color);
+// camadas sem cor recebem a variável no final.
+func coloredShadow(shadow string) string {
+	layers := splitTopLevel(shadow, ',')
+	for i, layer := range layers {
+		var parts []string
+		for _, part := range splitTopLevel(strings.TrimSpace(layer), ' ') {
+			if part != "" {
+				parts = append(parts, part)
+			}
+		}
+		colored := false
+		for j, part := range parts {
+			if strings.Contains(part, "var(") {
+				continue
+			}
+			if _, ok := colors.Parse(part); ok {
+				parts[j] = "var(--tw-shadow-color)"
+				colored = true
+			}
+		}
+		if !colored {
+			parts = append(parts, "var(--tw-shadow-color)")
+		}
+		layers[i] = strings.Join(parts, " ")
+	}
+	return strings.Join(layers, ", ")
+}
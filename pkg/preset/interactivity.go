@@ -0,0 +1,44 @@
+package preset
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/su3h7am/gocss/pkg/core"
+)
+
+// touchAction é a composição de touch-action usada pelos utilitários touch-pan-*.
+var touchAction = composeVars(touchVars)
+
+// getInteractivityRules retorna as regras que precisam de lógica; cursor,
+// will-change e os utilitários estáticos estão em rules.yaml.
+func getInteractivityRules() []core.Rule {
+	rules := []core.Rule{
+		// Stroke width: stroke-2, stroke-[3px]. Cores de stroke ficam para a regra de cores.
+		{
+			Matcher: regexp.MustCompile(`^stroke-(.+)$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				v, ok := arbitraryValue(match[1])
+				if ok {
+					if !isLength(v) && !numberRE.MatchString(v) {
+						return nil
+					}
+					v = strings.TrimPrefix(v, "length:")
+				} else if v, ok = lookupTheme(ctx.Theme, "strokeWidth", match[1]); !ok {
+					return nil
+				}
+				return &core.CSSEntry{Properties: map[string]string{"stroke-width": v}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+	}
+
+	for _, pan := range []struct{ name, variable string }{
+		{"pan-x", "--tw-pan-x"}, {"pan-left", "--tw-pan-x"}, {"pan-right", "--tw-pan-x"},
+		{"pan-y", "--tw-pan-y"}, {"pan-up", "--tw-pan-y"}, {"pan-down", "--tw-pan-y"},
+		{"pinch-zoom", "--tw-pinch-zoom"},
+	} {
+		rules = append(rules, staticRule("touch-"+pan.name, map[string]string{pan.variable: pan.name, "touch-action": touchAction}))
+	}
+	return rules
+}
@@ -0,0 +1,234 @@
+package preset
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/su3h7am/gocss/pkg/core"
+)
+
+func getLayoutRules() []core.Rule {
+	rules := []core.Rule{
+		staticRule("flex", map[string]string{"display": "flex"}),
+		staticRule("grid", map[string]string{"display": "grid"}),
+
+		// Flex
+		staticRule("flex-1", map[string]string{"flex": "1 1 0%"}),
+		staticRule("flex-auto", map[string]string{"flex": "1 1 auto"}),
+		staticRule("flex-initial", map[string]string{"flex": "0 1 auto"}),
+		staticRule("flex-none", map[string]string{"flex": "none"}),
+		arbitraryRule("flex", "flex"),
+		staticRule("grow", map[string]string{"flex-grow": "1"}),
+		numberRule("grow", "flex-grow"),
+		staticRule("shrink", map[string]string{"flex-shrink": "1"}),
+		numberRule("shrink", "flex-shrink"),
+		// Flex basis: basis-4, basis-1/2, basis-full, basis-[30%]
+		{
+			Matcher: regexp.MustCompile(`^basis-(.+)$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				v, ok := sizeValue(ctx.Theme, match[1])
+				if !ok {
+					return nil
+				}
+				return &core.CSSEntry{Properties: map[string]string{"flex-basis": v}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		// Order: order-2, -order-1, order-first
+		{
+			Matcher: regexp.MustCompile(`^(-?)order-(\d+|\[.+\])$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				v, ok := arbitraryValue(match[2])
+				if !ok {
+					v = match[2]
+				}
+				if match[1] == "-" {
+					v = negate(v)
+				}
+				return &core.CSSEntry{Properties: map[string]string{"order": v}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		staticRule("order-first", map[string]string{"order": "-9999"}),
+		staticRule("order-last", map[string]string{"order": "9999"}),
+		staticRule("order-none", map[string]string{"order": "0"}),
+
+		// Grid template: grid-cols-3, grid-cols-subgrid, grid-cols-[200px_1fr]
+		gridTemplateRule("cols", "grid-template-columns"),
+		gridTemplateRule("rows", "grid-template-rows"),
+		// Grid placement: col-span-2, col-start-1, row-end-auto, col-[1/3]
+		gridPlacementRule("col", "grid-column"),
+		gridPlacementRule("row", "grid-row"),
+		// Implicit tracks: auto-cols-fr, auto-rows-[minmax(0,2fr)]
+		gridAutoRule("cols", "grid-auto-columns"),
+		gridAutoRule("rows", "grid-auto-rows"),
+
+		// Gap: gap-4, gap-x-2, gap-y-px
+		{
+			Matcher: regexp.MustCompile(`^gap-(?:([xy])-)?(.+)$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				v, ok := spacingValue(ctx.Theme, match[2])
+				if !ok {
+					return nil
+				}
+				property := map[string]string{"": "gap", "x": "column-gap", "y": "row-gap"}[match[1]]
+				return &core.CSSEntry{Properties: map[string]string{property: v}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+	}
+
+	rules = append(rules, mappedRules("flex", "flex-direction", map[string]string{
+		"row": "row", "row-reverse": "row-reverse", "col": "column", "col-reverse": "column-reverse",
+	})...)
+	rules = append(rules, valueRules("flex", "flex-wrap", "wrap", "wrap-reverse", "nowrap")...)
+	rules = append(rules, mappedRules("grid-flow", "grid-auto-flow", map[string]string{
+		"row": "row", "col": "column", "dense": "dense", "row-dense": "row dense", "col-dense": "column dense",
+	})...)
+
+	// Alinhamento
+	rules = append(rules, mappedRules("justify", "justify-content", map[string]string{
+		"normal": "normal", "start": "flex-start", "end": "flex-end", "center": "center",
+		"between": "space-between", "around": "space-around", "evenly": "space-evenly", "stretch": "stretch",
+	})...)
+	rules = append(rules, valueRules("justify-items", "justify-items", "start", "end", "center", "stretch")...)
+	rules = append(rules, valueRules("justify-self", "justify-self", "auto", "start", "end", "center", "stretch")...)
+	rules = append(rules, mappedRules("content", "align-content", map[string]string{
+		"normal": "normal", "center": "center", "start": "flex-start", "end": "flex-end",
+		"between": "space-between", "around": "space-around", "evenly": "space-evenly",
+		"baseline": "baseline", "stretch": "stretch",
+	})...)
+	rules = append(rules, mappedRules("items", "align-items", map[string]string{
+		"start": "flex-start", "end": "flex-end", "center": "center", "baseline": "baseline", "stretch": "stretch",
+	})...)
+	rules = append(rules, mappedRules("self", "align-self", map[string]string{
+		"auto": "auto", "start": "flex-start", "end": "flex-end", "center": "center", "stretch": "stretch", "baseline": "baseline",
+	})...)
+	rules = append(rules, mappedRules("place-content", "place-content", map[string]string{
+		"center": "center", "start": "start", "end": "end", "between": "space-between",
+		"around": "space-around", "evenly": "space-evenly", "baseline": "baseline", "stretch": "stretch",
+	})...)
+	rules = append(rules, valueRules("place-items", "place-items", "start", "end", "center", "baseline", "stretch")...)
+	rules = append(rules, valueRules("place-self", "place-self", "auto", "start", "end", "center", "stretch")...)
+	return rules
+}
+
+// arbitraryRule cria a regra `<prefix>-[valor]` para uma propriedade.
+func arbitraryRule(prefix, property string) core.Rule {
+	return core.Rule{
+		Matcher: regexp.MustCompile(fmt.Sprintf(`^%s-(\[.+\])$`, regexp.QuoteMeta(prefix))),
+		Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+			v, _ := arbitraryValue(match[1])
+			return &core.CSSEntry{Properties: map[string]string{property: v}}
+		},
+		Meta: &core.RuleMeta{Layer: "utilities"},
+	}
+}
+
+// numberRule cria a regra `<prefix>-<n>` (ou arbitrária) que usa o número como valor.
+func numberRule(prefix, property string) core.Rule {
+	return core.Rule{
+		Matcher: regexp.MustCompile(fmt.Sprintf(`^%s-(\d+|\[.+\])$`, regexp.QuoteMeta(prefix))),
+		Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+			v, ok := arbitraryValue(match[1])
+			if !ok {
+				v = match[1]
+			}
+			return &core.CSSEntry{Properties: map[string]string{property: v}}
+		},
+		Meta: &core.RuleMeta{Layer: "utilities"},
+	}
+}
+
+func gridTemplateRule(axis, property string) core.Rule {
+	return core.Rule{
+		Matcher: regexp.MustCompile(fmt.Sprintf(`^grid-%s-(.+)$`, axis)),
+		Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+			var v string
+			switch key := match[1]; {
+			case key == "none" || key == "subgrid":
+				v = key
+			case numberRE.MatchString(key):
+				v = fmt.Sprintf("repeat(%s, minmax(0, 1fr))", key)
+			default:
+				var ok bool
+				if v, ok = arbitraryValue(key); !ok {
+					return nil
+				}
+			}
+			return &core.CSSEntry{Properties: map[string]string{property: v}}
+		},
+		Meta: &core.RuleMeta{Layer: "utilities"},
+	}
+}
+
+func gridPlacementRule(prefix, property string) core.Rule {
+	return core.Rule{
+		Matcher: regexp.MustCompile(fmt.Sprintf(`^%s-(?:(span|start|end)-)?(.+)$`, prefix)),
+		Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+			kind, key := match[1], match[2]
+			v, arbitrary := arbitraryValue(key)
+			if !arbitrary {
+				if key != "auto" && key != "full" && !numberRE.MatchString(key) {
+					return nil
+				}
+				v = key
+			}
+
+			switch kind {
+			case "span":
+				if v == "full" {
+					return &core.CSSEntry{Properties: map[string]string{property: "1 / -1"}}
+				}
+				if v == "auto" {
+					return nil
+				}
+				return &core.CSSEntry{Properties: map[string]string{property: fmt.Sprintf("span %s / span %s", v, v)}}
+			case "start", "end":
+				if v == "full" {
+					return nil
+				}
+				return &core.CSSEntry{Properties: map[string]string{property + "-" + kind: v}}
+			}
+			// col-auto ou col-[...]
+			if v == "full" || (!arbitrary && v != "auto") {
+				return nil
+			}
+			return &core.CSSEntry{Properties: map[string]string{property: v}}
+		},
+		Meta: &core.RuleMeta{Layer: "utilities"},
+	}
+}
+
+func gridAutoRule(axis, property string) core.Rule {
+	return core.Rule{
+		Matcher: regexp.MustCompile(fmt.Sprintf(`^auto-%s-(.+)$`, axis)),
+		Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+			v, ok := arbitraryValue(match[1])
+			if !ok {
+				if v, ok = map[string]string{
+					"auto": "auto", "min": "min-content", "max": "max-content", "fr": "minmax(0, 1fr)",
+				}[match[1]]; !ok {
+					return nil
+				}
+			}
+			return &core.CSSEntry{Properties: map[string]string{property: v}}
+		},
+		Meta: &core.RuleMeta{Layer: "utilities"},
+	}
+}
+
+// sizeValue resolve valores de tamanho: escala de espaçamento, frações,
+// `auto`, `full`, `px` e valores arbitrários.
+func sizeValue(theme map[string]interface{}, key string) (string, bool) {
+	switch key {
+	case "auto":
+		return "auto", true
+	case "full":
+		return "100%", true
+	}
+	if v, ok := fractionValue(key); ok {
+		return v, true
+	}
+	return spacingValue(theme, key)
+}
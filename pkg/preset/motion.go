@@ -0,0 +1,156 @@
+package preset
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/su3h7am/gocss/pkg/core"
+)
+
+// transform compõe todas as funções de transformação a partir das variáveis
+// registradas em transformVars.
+const transform = "translateX(var(--tw-translate-x)) translateY(var(--tw-translate-y)) translateZ(var(--tw-translate-z)) " +
+	"rotate(var(--tw-rotate)) rotateX(var(--tw-rotate-x)) rotateY(var(--tw-rotate-y)) " +
+	"skewX(var(--tw-skew-x)) skewY(var(--tw-skew-y)) " +
+	"scaleX(var(--tw-scale-x)) scaleY(var(--tw-scale-y)) scaleZ(var(--tw-scale-z))"
+
+func getMotionRules() []core.Rule {
+	rules := []core.Rule{
+		// Translate: translate-x-4, -translate-y-1/2, translate-z-[10px], translate-full
+		transformRule("translate", sizeValue),
+		// Rotate: rotate-45, -rotate-x-12, rotate-y-[30deg]
+		transformRule("rotate", degreeValue),
+		// Scale: scale-95, scale-x-150, -scale-y-100
+		transformRule("scale", ratioValue),
+		// Skew: skew-x-3, -skew-y-6
+		transformRule("skew", degreeValue),
+		staticRule("transform", map[string]string{"transform": transform}),
+		staticRule("transform-none", map[string]string{"transform": "none"}),
+		staticRule("transform-3d", map[string]string{"transform-style": "preserve-3d"}),
+		staticRule("transform-flat", map[string]string{"transform-style": "flat"}),
+		staticRule("backface-visible", map[string]string{"backface-visibility": "visible"}),
+		staticRule("backface-hidden", map[string]string{"backface-visibility": "hidden"}),
+		themeRule("perspective", "perspective", "perspective"),
+		arbitraryRule("origin", "transform-origin"),
+
+		// Transition: transition, transition-colors, transition-[height]
+		{
+			Matcher: regexp.MustCompile(`^transition(?:-(.+))?$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				property, ok := arbitraryValue(match[1])
+				if ok {
+					property = strings.ReplaceAll(property, " ", "")
+					property = strings.ReplaceAll(property, ",", ", ")
+				} else if property, ok = lookupTheme(ctx.Theme, "transitionProperty", match[1]); !ok {
+					return nil
+				}
+				if property == "none" {
+					return &core.CSSEntry{Properties: map[string]string{"transition-property": "none"}}
+				}
+				easing, _ := lookupTheme(ctx.Theme, "transitionTimingFunction", "DEFAULT")
+				duration, _ := lookupTheme(ctx.Theme, "transitionDuration", "DEFAULT")
+				return &core.CSSEntry{Properties: map[string]string{
+					"transition-property":        property,
+					"transition-timing-function": easing,
+					"transition-duration":        duration,
+				}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		timeRule("duration", "transition-duration"),
+		timeRule("delay", "transition-delay"),
+		themeRule("ease", "transitionTimingFunction", "transition-timing-function"),
+
+		// Animation: animate-spin, animate-[wiggle_1s_ease-in-out_infinite]
+		{
+			Matcher: regexp.MustCompile(`^animate-(.+)$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				animation, ok := arbitraryValue(match[1])
+				if !ok {
+					if animation, ok = lookupTheme(ctx.Theme, "animation", match[1]); !ok {
+						return nil
+					}
+				}
+				fields := strings.Fields(animation)
+				if len(fields) == 0 {
+					return nil
+				}
+				entry := &core.CSSEntry{Properties: map[string]string{"animation": animation}}
+				// Os @keyframes só são emitidos quando a animação é usada
+				name := fields[0]
+				if frames, ok := lookupTheme(ctx.Theme, "keyframes", name); ok {
+					entry.Globals = []string{keyframesCSS(name, frames)}
+				}
+				return entry
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+	}
+
+	rules = append(rules, mappedRules("origin", "transform-origin", map[string]string{
+		"center": "center", "top": "top", "top-right": "top right", "right": "right", "bottom-right": "bottom right",
+		"bottom": "bottom", "bottom-left": "bottom left", "left": "left", "top-left": "top left",
+	})...)
+	return rules
+}
+
+// transformRule cria a regra `[-]<nome>[-x|-y|-z]-<valor>`. Sem eixo, o valor é
+// aplicado a x e y (ou à rotação 2D, no caso de rotate).
+func transformRule(name string, value func(theme map[string]interface{}, key string) (string, bool)) core.Rule {
+	return core.Rule{
+		Matcher: regexp.MustCompile(fmt.Sprintf(`^(-?)%s-(?:([xyz])-)?(.+)$`, name)),
+		Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+			axis := match[2]
+			if name == "skew" && (axis == "" || axis == "z") {
+				return nil
+			}
+			v, ok := value(ctx.Theme, match[3])
+			if !ok {
+				return nil
+			}
+			if match[1] == "-" {
+				v = negate(v)
+			}
+
+			props := map[string]string{"transform": transform}
+			switch {
+			case axis != "":
+				props["--tw-"+name+"-"+axis] = v
+			case name == "rotate":
+				props["--tw-rotate"] = v
+			default:
+				props["--tw-"+name+"-x"] = v
+				props["--tw-"+name+"-y"] = v
+			}
+			return &core.CSSEntry{Properties: props}
+		},
+		Meta: &core.RuleMeta{Layer: "utilities"},
+	}
+}
+
+// timeRule cria a regra `<prefix>-<ms>` (ex.: duration-300 -> 300ms).
+func timeRule(prefix, property string) core.Rule {
+	return core.Rule{
+		Matcher: regexp.MustCompile(fmt.Sprintf(`^%s-(\d+|\[.+\])$`, prefix)),
+		Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+			v, ok := arbitraryValue(match[1])
+			if !ok {
+				v = match[1] + "ms"
+			}
+			return &core.CSSEntry{Properties: map[string]string{property: v}}
+		},
+		Meta: &core.RuleMeta{Layer: "utilities"},
+	}
+}
+
+// keyframesCSS monta o bloco @keyframes com o corpo indentado.
+func keyframesCSS(name, frames string) string {
+	var sb strings.Builder
+	sb.WriteString("@keyframes " + name + " {\n")
+	for _, line := range strings.Split(strings.TrimSpace(frames), "\n") {
+		sb.WriteString("  " + line + "\n")
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
@@ -0,0 +1,110 @@
+package preset
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/su3h7am/gocss/pkg/core"
+)
+
+// insetProperties mapeia os prefixos de posicionamento para as propriedades CSS.
+var insetProperties = map[string][]string{
+	"inset":   {"inset"},
+	"inset-x": {"left", "right"},
+	"inset-y": {"top", "bottom"},
+	"top":     {"top"},
+	"right":   {"right"},
+	"bottom":  {"bottom"},
+	"left":    {"left"},
+	"start":   {"inset-inline-start"},
+	"end":     {"inset-inline-end"},
+}
+
+// insetRule cria uma regra de posicionamento para os prefixos de insetProperties
+// capturados por pattern (sinal, prefixo e valor).
+func insetRule(pattern string) core.Rule {
+	return core.Rule{
+		Matcher: regexp.MustCompile(pattern),
+		Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+			v, ok := sizeValue(ctx.Theme, match[3])
+			if !ok {
+				return nil
+			}
+			if match[1] == "-" {
+				v = negate(v)
+			}
+			props := map[string]string{}
+			for _, p := range insetProperties[match[2]] {
+				props[p] = v
+			}
+			return &core.CSSEntry{Properties: props}
+		},
+		Meta: &core.RuleMeta{Layer: "utilities"},
+	}
+}
+
+func getPositionRules() []core.Rule {
+	rules := []core.Rule{
+		// Inset: inset-0, inset-x-auto. As shorthands vêm antes dos lados, para
+		// que `inset-0 -top-4` termine com o top negativo.
+		insetRule(`^(-?)(inset-[xy]|inset)-(.+)$`),
+		// Lados: -top-4, left-1/2, top-[3px], start-0
+		insetRule(`^(-?)(top|right|bottom|left|start|end)-(.+)$`),
+		// Z-index: z-10, -z-10, z-auto, z-[100]
+		{
+			Matcher: regexp.MustCompile(`^(-?)z-(\d+|auto|\[.+\])$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				v, ok := arbitraryValue(match[2])
+				if !ok {
+					v = match[2]
+				}
+				if match[1] == "-" {
+					if v == "auto" {
+						return nil
+					}
+					v = negate(v)
+				}
+				return &core.CSSEntry{Properties: map[string]string{"z-index": v}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		// Aspect ratio: aspect-video, aspect-4/3, aspect-[4/3]
+		{
+			Matcher: regexp.MustCompile(`^aspect-(.+)$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				v, ok := arbitraryValue(match[1])
+				if !ok {
+					if v, ok = lookupTheme(ctx.Theme, "aspectRatio", match[1]); !ok {
+						num, den, found := strings.Cut(match[1], "/")
+						if !found || !numberRE.MatchString(num) || !numberRE.MatchString(den) {
+							return nil
+						}
+						v = num + " / " + den
+					}
+				}
+				return &core.CSSEntry{Properties: map[string]string{"aspect-ratio": v}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		// Columns: columns-3, columns-xs, columns-auto, columns-[10rem]
+		{
+			Matcher: regexp.MustCompile(`^columns-(.+)$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				v, ok := arbitraryValue(match[1])
+				if !ok {
+					if v, ok = lookupTheme(ctx.Theme, "columns", match[1]); !ok {
+						if match[1] != "auto" && !numberRE.MatchString(match[1]) {
+							return nil
+						}
+						v = match[1]
+					}
+				}
+				return &core.CSSEntry{Properties: map[string]string{"columns": v}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+	}
+
+	// Os utilitários estáticos (display, visibility, overflow, ...) estão em rules.yaml.
+	return append(rules, arbitraryRule("object", "object-position"))
+}
@@ -0,0 +1,116 @@
+package preset
+
+import (
+	"strings"
+
+	"github.com/su3h7am/gocss/pkg/core"
+)
+
+// cssVar é uma variável usada na composição de utilitários, com seu valor padrão.
+type cssVar struct {
+	name  string
+	value string
+}
+
+// Famílias de variáveis de composição. Os valores padrão são registrados uma
+// única vez pelo preflight de propriedades; cada utilitário define apenas a
+// própria variável e repete a composição completa (ex.: `filter`).
+var (
+	numericVars = []cssVar{
+		{"--tw-ordinal", ""},
+		{"--tw-slashed-zero", ""},
+		{"--tw-numeric-figure", ""},
+		{"--tw-numeric-spacing", ""},
+		{"--tw-numeric-fraction", ""},
+	}
+	shadowVars = []cssVar{
+		{"--tw-ring-inset", ""},
+		{"--tw-ring-offset-width", "0px"},
+		{"--tw-ring-offset-color", "#fff"},
+		{"--tw-ring-color", "rgb(59 130 246 / 0.5)"},
+		{"--tw-ring-offset-shadow", "0 0 #0000"},
+		{"--tw-ring-shadow", "0 0 #0000"},
+		{"--tw-shadow", "0 0 #0000"},
+		{"--tw-shadow-colored", "0 0 #0000"},
+	}
+	gradientVars = []cssVar{
+		{"--tw-gradient-from-position", ""},
+		{"--tw-gradient-via-position", ""},
+		{"--tw-gradient-to-position", ""},
+	}
+	contentVars = []cssVar{
+		{"--tw-content", "''"},
+	}
+	touchVars = []cssVar{
+		{"--tw-pan-x", ""},
+		{"--tw-pan-y", ""},
+		{"--tw-pinch-zoom", ""},
+	}
+	snapVars = []cssVar{
+		{"--tw-scroll-snap-strictness", "proximity"},
+	}
+	transformVars = []cssVar{
+		{"--tw-translate-x", "0"},
+		{"--tw-translate-y", "0"},
+		{"--tw-translate-z", "0"},
+		{"--tw-rotate", "0"},
+		{"--tw-rotate-x", "0"},
+		{"--tw-rotate-y", "0"},
+		{"--tw-skew-x", "0"},
+		{"--tw-skew-y", "0"},
+		{"--tw-scale-x", "1"},
+		{"--tw-scale-y", "1"},
+		{"--tw-scale-z", "1"},
+	}
+	filterVars = []cssVar{
+		{"--tw-blur", ""},
+		{"--tw-brightness", ""},
+		{"--tw-contrast", ""},
+		{"--tw-grayscale", ""},
+		{"--tw-hue-rotate", ""},
+		{"--tw-invert", ""},
+		{"--tw-saturate", ""},
+		{"--tw-sepia", ""},
+		{"--tw-drop-shadow", ""},
+	}
+	backdropVars = []cssVar{
+		{"--tw-backdrop-blur", ""},
+		{"--tw-backdrop-brightness", ""},
+		{"--tw-backdrop-contrast", ""},
+		{"--tw-backdrop-grayscale", ""},
+		{"--tw-backdrop-hue-rotate", ""},
+		{"--tw-backdrop-invert", ""},
+		{"--tw-backdrop-opacity", ""},
+		{"--tw-backdrop-saturate", ""},
+		{"--tw-backdrop-sepia", ""},
+	}
+)
+
+// composeVars monta a composição `var(--a) var(--b) ...` de uma família.
+func composeVars(vars []cssVar) string {
+	parts := make([]string, len(vars))
+	for i, v := range vars {
+		parts[i] = "var(" + v.name + ")"
+	}
+	return strings.Join(parts, " ")
+}
+
+// propertiesPreflight registra os valores padrão das variáveis de composição
+// na camada "properties", para que `var(--tw-blur)` seja válido mesmo quando
+// nenhum utilitário de blur é usado.
+func propertiesPreflight(groups ...[]cssVar) core.Preflight {
+	return core.Preflight{
+		Layer: "properties",
+		GetCSS: func(ctx *core.PreflightContext) string {
+			var sb strings.Builder
+			sb.WriteString("*, ::before, ::after, ::backdrop {\n")
+			for _, group := range groups {
+				for _, v := range group {
+					sb.WriteString("  " + v.name + ": " + v.value + ";\n")
+				}
+			}
+			sb.WriteString("}")
+			return sb.String()
+		},
+	}
+}
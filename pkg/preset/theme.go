@@ -0,0 +1,389 @@
+package preset
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/su3h7am/gocss/pkg/core"
+)
+
+// colorShades são as tonalidades de cada cor da paleta, na ordem usada em windPalette.
+var colorShades = []string{"50", "100", "200", "300", "400", "500", "600", "700", "800", "900", "950"}
+
+// windPalette é a paleta de cores padrão do Tailwind CSS.
+var windPalette = map[string][]string{
+	"slate":   {"#f8fafc", "#f1f5f9", "#e2e8f0", "#cbd5e1", "#94a3b8", "#64748b", "#475569", "#334155", "#1e293b", "#0f172a", "#020617"},
+	"gray":    {"#f9fafb", "#f3f4f6", "#e5e7eb", "#d1d5db", "#9ca3af", "#6b7280", "#4b5563", "#374151", "#1f2937", "#111827", "#030712"},
+	"zinc":    {"#fafafa", "#f4f4f5", "#e4e4e7", "#d4d4d8", "#a1a1aa", "#71717a", "#52525b", "#3f3f46", "#27272a", "#18181b", "#09090b"},
+	"neutral": {"#fafafa", "#f5f5f5", "#e5e5e5", "#d4d4d4", "#a3a3a3", "#737373", "#525252", "#404040", "#262626", "#171717", "#0a0a0a"},
+	"stone":   {"#fafaf9", "#f5f5f4", "#e7e5e4", "#d6d3d1", "#a8a29e", "#78716c", "#57534e", "#44403c", "#292524", "#1c1917", "#0c0a09"},
+	"red":     {"#fef2f2", "#fee2e2", "#fecaca", "#fca5a5", "#f87171", "#ef4444", "#dc2626", "#b91c1c", "#991b1b", "#7f1d1d", "#450a0a"},
+	"orange":  {"#fff7ed", "#ffedd5", "#fed7aa", "#fdba74", "#fb923c", "#f97316", "#ea580c", "#c2410c", "#9a3412", "#7c2d12", "#431407"},
+	"amber":   {"#fffbeb", "#fef3c7", "#fde68a", "#fcd34d", "#fbbf24", "#f59e0b", "#d97706", "#b45309", "#92400e", "#78350f", "#451a03"},
+	"yellow":  {"#fefce8", "#fef9c3", "#fef08a", "#fde047", "#facc15", "#eab308", "#ca8a04", "#a16207", "#854d0e", "#713f12", "#422006"},
+	"lime":    {"#f7fee7", "#ecfccb", "#d9f99d", "#bef264", "#a3e635", "#84cc16", "#65a30d", "#4d7c0f", "#3f6212", "#365314", "#1a2e05"},
+	"green":   {"#f0fdf4", "#dcfce7", "#bbf7d0", "#86efac", "#4ade80", "#22c55e", "#16a34a", "#15803d", "#166534", "#14532d", "#052e16"},
+	"emerald": {"#ecfdf5", "#d1fae5", "#a7f3d0", "#6ee7b7", "#34d399", "#10b981", "#059669", "#047857", "#065f46", "#064e3b", "#022c22"},
+	"teal":    {"#f0fdfa", "#ccfbf1", "#99f6e4", "#5eead4", "#2dd4bf", "#14b8a6", "#0d9488", "#0f766e", "#115e59", "#134e4a", "#042f2e"},
+	"cyan":    {"#ecfeff", "#cffafe", "#a5f3fc", "#67e8f9", "#22d3ee", "#06b6d4", "#0891b2", "#0e7490", "#155e75", "#164e63", "#083344"},
+	"sky":     {"#f0f9ff", "#e0f2fe", "#bae6fd", "#7dd3fc", "#38bdf8", "#0ea5e9", "#0284c7", "#0369a1", "#075985", "#0c4a6e", "#082f49"},
+	"blue":    {"#eff6ff", "#dbeafe", "#bfdbfe", "#93c5fd", "#60a5fa", "#3b82f6", "#2563eb", "#1d4ed8", "#1e40af", "#1e3a8a", "#172554"},
+	"indigo":  {"#eef2ff", "#e0e7ff", "#c7d2fe", "#a5b4fc", "#818cf8", "#6366f1", "#4f46e5", "#4338ca", "#3730a3", "#312e81", "#1e1b4b"},
+	"violet":  {"#f5f3ff", "#ede9fe", "#ddd6fe", "#c4b5fd", "#a78bfa", "#8b5cf6", "#7c3aed", "#6d28d9", "#5b21b6", "#4c1d95", "#2e1065"},
+	"purple":  {"#faf5ff", "#f3e8ff", "#e9d5ff", "#d8b4fe", "#c084fc", "#a855f7", "#9333ea", "#7e22ce", "#6b21a8", "#581c87", "#3b0764"},
+	"fuchsia": {"#fdf4ff", "#fae8ff", "#f5d0fe", "#f0abfc", "#e879f9", "#d946ef", "#c026d3", "#a21caf", "#86198f", "#701a75", "#4a044e"},
+	"pink":    {"#fdf2f8", "#fce7f3", "#fbcfe8", "#f9a8d4", "#f472b6", "#ec4899", "#db2777", "#be185d", "#9d174d", "#831843", "#500724"},
+	"rose":    {"#fff1f2", "#ffe4e6", "#fecdd3", "#fda4af", "#fb7185", "#f43f5e", "#e11d48", "#be123c", "#9f1239", "#881337", "#4c0519"},
+}
+
+// windTheme retorna o tema padrão do preset. Um novo mapa é criado a cada
+// chamada para que a mesclagem com o tema do usuário não altere os valores padrão.
+func windTheme() map[string]interface{} {
+	palette := map[string]interface{}{
+		"inherit":     "inherit",
+		"current":     "currentColor",
+		"transparent": "transparent",
+		"black":       "#000",
+		"white":       "#fff",
+	}
+	for name, hexes := range windPalette {
+		shades := make(map[string]interface{}, len(hexes))
+		for i, hex := range hexes {
+			shades[colorShades[i]] = hex
+		}
+		palette[name] = shades
+	}
+
+	return map[string]interface{}{
+		"colors":  palette,
+		"spacing": windSpacing(),
+		"screens": map[string]interface{}{
+			"sm":  "640px",
+			"md":  "768px",
+			"lg":  "1024px",
+			"xl":  "1280px",
+			"2xl": "1536px",
+		},
+		"aria": map[string]interface{}{
+			"busy":     `busy="true"`,
+			"checked":  `checked="true"`,
+			"disabled": `disabled="true"`,
+			"expanded": `expanded="true"`,
+			"hidden":   `hidden="true"`,
+			"pressed":  `pressed="true"`,
+			"readonly": `readonly="true"`,
+			"required": `required="true"`,
+			"selected": `selected="true"`,
+		},
+		"data":     map[string]interface{}{},
+		"supports": map[string]interface{}{},
+		"containers": map[string]interface{}{
+			"xs": "20rem", "sm": "24rem", "md": "28rem", "lg": "32rem", "xl": "36rem", "2xl": "42rem",
+			"3xl": "48rem", "4xl": "56rem", "5xl": "64rem", "6xl": "72rem", "7xl": "80rem",
+		},
+		"fontSize": map[string]interface{}{
+			"xs":   []string{"0.75rem", "1rem"},
+			"sm":   []string{"0.875rem", "1.25rem"},
+			"base": []string{"1rem", "1.5rem"},
+			"lg":   []string{"1.125rem", "1.75rem"},
+			"xl":   []string{"1.25rem", "1.75rem"},
+			"2xl":  []string{"1.5rem", "2rem"},
+			"3xl":  []string{"1.875rem", "2.25rem"},
+			"4xl":  []string{"2.25rem", "2.5rem"},
+			"5xl":  []string{"3rem", "1"},
+			"6xl":  []string{"3.75rem", "1"},
+			"7xl":  []string{"4.5rem", "1"},
+			"8xl":  []string{"6rem", "1"},
+			"9xl":  []string{"8rem", "1"},
+		},
+		"fontWeight": map[string]interface{}{
+			"thin":       "100",
+			"extralight": "200",
+			"light":      "300",
+			"normal":     "400",
+			"medium":     "500",
+			"semibold":   "600",
+			"bold":       "700",
+			"extrabold":  "800",
+			"black":      "900",
+		},
+		"fontFamily": map[string]interface{}{
+			"sans":  []string{"ui-sans-serif", "system-ui", "sans-serif", `"Apple Color Emoji"`, `"Segoe UI Emoji"`, `"Segoe UI Symbol"`, `"Noto Color Emoji"`},
+			"serif": []string{"ui-serif", "Georgia", "Cambria", `"Times New Roman"`, "Times", "serif"},
+			"mono":  []string{"ui-monospace", "SFMono-Regular", "Menlo", "Monaco", "Consolas", `"Liberation Mono"`, `"Courier New"`, "monospace"},
+		},
+		"lineHeight": map[string]interface{}{
+			"none":    "1",
+			"tight":   "1.25",
+			"snug":    "1.375",
+			"normal":  "1.5",
+			"relaxed": "1.625",
+			"loose":   "2",
+		},
+		"letterSpacing": map[string]interface{}{
+			"tighter": "-0.05em",
+			"tight":   "-0.025em",
+			"normal":  "0em",
+			"wide":    "0.025em",
+			"wider":   "0.05em",
+			"widest":  "0.1em",
+		},
+		"borderRadius": map[string]interface{}{
+			"none":    "0px",
+			"sm":      "0.125rem",
+			"DEFAULT": "0.25rem",
+			"md":      "0.375rem",
+			"lg":      "0.5rem",
+			"xl":      "0.75rem",
+			"2xl":     "1rem",
+			"3xl":     "1.5rem",
+			"full":    "9999px",
+		},
+		"borderWidth":     widthScale("1px"),
+		"ringWidth":       widthScale("3px"),
+		"ringOffsetWidth": widthScale(""),
+		"outlineWidth":    widthScale(""),
+		"outlineOffset":   widthScale(""),
+		"columns": map[string]interface{}{
+			"3xs": "16rem",
+			"2xs": "18rem",
+			"xs":  "20rem",
+			"sm":  "24rem",
+			"md":  "28rem",
+			"lg":  "32rem",
+			"xl":  "36rem",
+			"2xl": "42rem",
+			"3xl": "48rem",
+			"4xl": "56rem",
+			"5xl": "64rem",
+			"6xl": "72rem",
+			"7xl": "80rem",
+		},
+		"boxShadow": map[string]interface{}{
+			"sm":      "0 1px 2px 0 rgb(0 0 0 / 0.05)",
+			"DEFAULT": "0 1px 3px 0 rgb(0 0 0 / 0.1), 0 1px 2px -1px rgb(0 0 0 / 0.1)",
+			"md":      "0 4px 6px -1px rgb(0 0 0 / 0.1), 0 2px 4px -2px rgb(0 0 0 / 0.1)",
+			"lg":      "0 10px 15px -3px rgb(0 0 0 / 0.1), 0 4px 6px -4px rgb(0 0 0 / 0.1)",
+			"xl":      "0 20px 25px -5px rgb(0 0 0 / 0.1), 0 8px 10px -6px rgb(0 0 0 / 0.1)",
+			"2xl":     "0 25px 50px -12px rgb(0 0 0 / 0.25)",
+			"inner":   "inset 0 2px 4px 0 rgb(0 0 0 / 0.05)",
+			"none":    "none",
+		},
+		"dropShadow": map[string]interface{}{
+			"sm":      "0 1px 1px rgb(0 0 0 / 0.05)",
+			"DEFAULT": []string{"0 1px 2px rgb(0 0 0 / 0.1)", "0 1px 1px rgb(0 0 0 / 0.06)"},
+			"md":      []string{"0 4px 3px rgb(0 0 0 / 0.07)", "0 2px 2px rgb(0 0 0 / 0.06)"},
+			"lg":      []string{"0 10px 8px rgb(0 0 0 / 0.04)", "0 4px 3px rgb(0 0 0 / 0.1)"},
+			"xl":      []string{"0 20px 13px rgb(0 0 0 / 0.03)", "0 8px 5px rgb(0 0 0 / 0.08)"},
+			"2xl":     "0 25px 25px rgb(0 0 0 / 0.15)",
+			"none":    "0 0 #0000",
+		},
+		"blur": map[string]interface{}{
+			"none":    "0",
+			"sm":      "4px",
+			"DEFAULT": "8px",
+			"md":      "12px",
+			"lg":      "16px",
+			"xl":      "24px",
+			"2xl":     "40px",
+			"3xl":     "64px",
+		},
+		"transitionProperty": map[string]interface{}{
+			"none":      "none",
+			"all":       "all",
+			"DEFAULT":   "color, background-color, border-color, text-decoration-color, fill, stroke, opacity, box-shadow, transform, filter, backdrop-filter",
+			"colors":    "color, background-color, border-color, text-decoration-color, fill, stroke",
+			"opacity":   "opacity",
+			"shadow":    "box-shadow",
+			"transform": "transform",
+		},
+		"transitionTimingFunction": map[string]interface{}{
+			"DEFAULT": "cubic-bezier(0.4, 0, 0.2, 1)",
+			"linear":  "linear",
+			"in":      "cubic-bezier(0.4, 0, 1, 1)",
+			"out":     "cubic-bezier(0, 0, 0.2, 1)",
+			"in-out":  "cubic-bezier(0.4, 0, 0.2, 1)",
+		},
+		"transitionDuration": map[string]interface{}{
+			"DEFAULT": "150ms",
+		},
+		"animation": map[string]interface{}{
+			"none":   "none",
+			"spin":   "spin 1s linear infinite",
+			"ping":   "ping 1s cubic-bezier(0, 0, 0.2, 1) infinite",
+			"pulse":  "pulse 2s cubic-bezier(0.4, 0, 0.6, 1) infinite",
+			"bounce": "bounce 1s infinite",
+		},
+		"keyframes": map[string]interface{}{
+			"spin":  "to {\n  transform: rotate(360deg);\n}",
+			"ping":  "75%, 100% {\n  transform: scale(2);\n  opacity: 0;\n}",
+			"pulse": "50% {\n  opacity: 0.5;\n}",
+			"bounce": "0%, 100% {\n  transform: translateY(-25%);\n  animation-timing-function: cubic-bezier(0.8, 0, 1, 1);\n}\n" +
+				"50% {\n  transform: none;\n  animation-timing-function: cubic-bezier(0, 0, 0.2, 1);\n}",
+		},
+		"perspective": map[string]interface{}{
+			"dramatic": "100px",
+			"near":     "300px",
+			"normal":   "500px",
+			"midrange": "800px",
+			"distant":  "1200px",
+			"none":     "none",
+		},
+		"aspectRatio": map[string]interface{}{
+			"auto":   "auto",
+			"square": "1 / 1",
+			"video":  "16 / 9",
+		},
+		"strokeWidth": map[string]interface{}{
+			"0": "0",
+			"1": "1",
+			"2": "2",
+		},
+		"cursor": map[string]interface{}{
+			"auto": "auto", "default": "default", "pointer": "pointer", "wait": "wait", "text": "text",
+			"move": "move", "help": "help", "not-allowed": "not-allowed", "none": "none",
+			"context-menu": "context-menu", "progress": "progress", "cell": "cell", "crosshair": "crosshair",
+			"vertical-text": "vertical-text", "alias": "alias", "copy": "copy", "no-drop": "no-drop",
+			"grab": "grab", "grabbing": "grabbing", "all-scroll": "all-scroll",
+			"col-resize": "col-resize", "row-resize": "row-resize",
+			"n-resize": "n-resize", "e-resize": "e-resize", "s-resize": "s-resize", "w-resize": "w-resize",
+			"ne-resize": "ne-resize", "nw-resize": "nw-resize", "se-resize": "se-resize", "sw-resize": "sw-resize",
+			"ew-resize": "ew-resize", "ns-resize": "ns-resize", "nesw-resize": "nesw-resize", "nwse-resize": "nwse-resize",
+			"zoom-in": "zoom-in", "zoom-out": "zoom-out",
+		},
+		"willChange": map[string]interface{}{
+			"auto":      "auto",
+			"scroll":    "scroll-position",
+			"contents":  "contents",
+			"transform": "transform",
+		},
+	}
+}
+
+// widthScale retorna a escala de larguras em pixels (0, 1, 2, 4, 8) usada por
+// bordas, rings e outlines, com o valor DEFAULT opcional.
+func widthScale(def string) map[string]interface{} {
+	scale := map[string]interface{}{
+		"0": "0px",
+		"1": "1px",
+		"2": "2px",
+		"4": "4px",
+		"8": "8px",
+	}
+	if def != "" {
+		scale["DEFAULT"] = def
+	}
+	return scale
+}
+
+// windSpacing retorna a escala de espaçamento padrão (1 unidade = 0.25rem).
+func windSpacing() map[string]interface{} {
+	spacing := map[string]interface{}{
+		"0":  "0px",
+		"px": "1px",
+	}
+	for _, key := range []string{
+		"0.5", "1", "1.5", "2", "2.5", "3", "3.5", "4", "5", "6", "7", "8", "9", "10", "11", "12",
+		"14", "16", "20", "24", "28", "32", "36", "40", "44", "48", "52", "56", "60", "64", "72", "80", "96",
+	} {
+		n, _ := strconv.ParseFloat(key, 64)
+		spacing[key] = formatNumber(n*0.25) + "rem"
+	}
+	return spacing
+}
+
+// themeSection retorna uma seção do tema (ex.: "colors") como mapa.
+func themeSection(theme map[string]interface{}, section string) map[string]interface{} {
+	m, _ := theme[section].(map[string]interface{})
+	return m
+}
+
+// lookupTheme procura uma chave em uma seção do tema (veja core.ThemeValue).
+func lookupTheme(theme map[string]interface{}, section, key string) (string, bool) {
+	return core.ThemeValue(theme, section, key)
+}
+
+// lookupThemeList é como lookupTheme, mas devolve os itens de um valor em lista
+// (ex.: tamanho e altura de linha de fontSize).
+func lookupThemeList(theme map[string]interface{}, section, key string) ([]string, bool) {
+	switch v := themeSection(theme, section)[key].(type) {
+	case string:
+		return []string{v}, true
+	case []string:
+		return v, len(v) > 0
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				items = append(items, s)
+			}
+		}
+		return items, len(items) > 0
+	}
+	return nil, false
+}
+
+// breakpoint é um ponto de quebra do tema (seção "screens").
+type breakpoint struct {
+	name string
+	size string
+}
+
+// themeBreakpoints retorna os breakpoints de uma seção do tema ordenados pela
+// largura, do menor para o maior.
+func themeBreakpoints(theme map[string]interface{}, section string) []breakpoint {
+	var points []breakpoint
+	for name, v := range themeSection(theme, section) {
+		if size, ok := core.ThemeString(v); ok {
+			points = append(points, breakpoint{name, size})
+		}
+	}
+	sort.Slice(points, func(i, j int) bool {
+		a, b := core.SizeInPixels(points[i].size), core.SizeInPixels(points[j].size)
+		if a != b {
+			return a < b
+		}
+		return points[i].name < points[j].name
+	})
+	return points
+}
+
+// lookupColor procura uma cor na seção "colors" do tema. Nomes compostos como
+// "red-500" ou "brand-primary-light" são resolvidos descendo pelos mapas
+// aninhados; um mapa sem tonalidade usa a chave "DEFAULT".
+func lookupColor(theme map[string]interface{}, name string) (string, bool) {
+	return lookupNested(themeSection(theme, "colors"), name)
+}
+
+func lookupNested(m map[string]interface{}, name string) (string, bool) {
+	if m == nil || name == "" {
+		return "", false
+	}
+	switch v := m[name].(type) {
+	case string:
+		return v, true
+	case map[string]interface{}:
+		if def, ok := v["DEFAULT"].(string); ok {
+			return def, true
+		}
+	}
+	// Tenta dividir o nome em prefixo e restante ("red-500" -> "red", "500")
+	for i := strings.Index(name, "-"); i > 0; i = nextIndex(name, "-", i) {
+		if sub, ok := m[name[:i]].(map[string]interface{}); ok {
+			if v, ok := lookupNested(sub, name[i+1:]); ok {
+				return v, true
+			}
+		}
+	}
+	return "", false
+}
+
+// nextIndex retorna o índice da próxima ocorrência de sep após i, ou -1.
+func nextIndex(s, sep string, i int) int {
+	j := strings.Index(s[i+1:], sep)
+	if j < 0 {
+		return -1
+	}
+	return i + 1 + j
+}
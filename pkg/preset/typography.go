@@ -0,0 +1,234 @@
+package preset
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/su3h7am/gocss/pkg/core"
+)
+
+// fontVariantNumeric compõe os utilitários de font-variant-numeric, permitindo
+// combinar `ordinal tabular-nums` na mesma declaração.
+var fontVariantNumeric = composeVars(numericVars)
+
+func getTypographyRules() []core.Rule {
+	rules := []core.Rule{
+		// Content de pseudo-elementos: content-none, content-['→'], content-[attr(data-label)]
+		{
+			Matcher: regexp.MustCompile(`^content-(none|\[.+\])$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				v, ok := arbitraryValue(match[1])
+				if !ok {
+					v = "none"
+				}
+				return &core.CSSEntry{Properties: map[string]string{"--tw-content": v, "content": "var(--tw-content)"}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		// Font size, com altura de linha opcional: text-sm, text-sm/6, text-[2rem]/[1.1]
+		{
+			Matcher: regexp.MustCompile(`^text-(.+)$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				size, lineHeight := splitModifier(match[1])
+
+				props := map[string]string{}
+				if v, ok := arbitraryValue(size); ok {
+					if !isLength(v) {
+						return nil
+					}
+					props["font-size"] = strings.TrimPrefix(v, "length:")
+				} else if values, ok := lookupThemeList(ctx.Theme, "fontSize", size); ok {
+					props["font-size"] = values[0]
+					if len(values) > 1 {
+						props["line-height"] = values[1]
+					}
+				} else {
+					return nil
+				}
+
+				if lineHeight != "" {
+					v, ok := lineHeightValue(ctx.Theme, lineHeight)
+					if !ok {
+						return nil
+					}
+					props["line-height"] = v
+				}
+				return &core.CSSEntry{Properties: props}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		// Font weight: font-bold, font-[550]
+		{
+			Matcher: regexp.MustCompile(`^font-(.+)$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				value, ok := arbitraryValue(match[1])
+				if ok && !numberRE.MatchString(value) {
+					return nil
+				}
+				if !ok {
+					if value, ok = lookupTheme(ctx.Theme, "fontWeight", match[1]); !ok {
+						return nil
+					}
+				}
+				return &core.CSSEntry{Properties: map[string]string{"font-weight": value}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		// Font family: font-sans, font-['Inter']
+		themeRule("font", "fontFamily", "font-family"),
+		// Line height: leading-tight, leading-6, leading-[1.1]
+		{
+			Matcher: regexp.MustCompile(`^leading-(.+)$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				v, ok := lineHeightValue(ctx.Theme, match[1])
+				if !ok {
+					return nil
+				}
+				return &core.CSSEntry{Properties: map[string]string{"line-height": v}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		themeRule("tracking", "letterSpacing", "letter-spacing"),
+		// Text indent: indent-4, -indent-px
+		{
+			Matcher: regexp.MustCompile(`^(-?)indent-(.+)$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				v, ok := spacingValue(ctx.Theme, match[2])
+				if !ok {
+					return nil
+				}
+				if match[1] == "-" {
+					v = negate(v)
+				}
+				return &core.CSSEntry{Properties: map[string]string{"text-indent": v}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		// Line clamp: line-clamp-3
+		{
+			Matcher: regexp.MustCompile(`^line-clamp-(\d+|\[.+\])$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				lines, ok := arbitraryValue(match[1])
+				if !ok {
+					lines = match[1]
+				}
+				return &core.CSSEntry{Properties: map[string]string{
+					"overflow":           "hidden",
+					"display":            "-webkit-box",
+					"-webkit-box-orient": "vertical",
+					"-webkit-line-clamp": lines,
+				}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		staticRule("line-clamp-none", map[string]string{
+			"overflow":           "visible",
+			"display":            "block",
+			"-webkit-box-orient": "horizontal",
+			"-webkit-line-clamp": "none",
+		}),
+		// Text decoration thickness: decoration-2, decoration-from-font, decoration-[3px]
+		{
+			Matcher: regexp.MustCompile(`^decoration-(auto|from-font|\d+|\[.+\])$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				v := pixelValue(match[1])
+				if v == "" {
+					return nil
+				}
+				return &core.CSSEntry{Properties: map[string]string{"text-decoration-thickness": v}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+		// Underline offset: underline-offset-4, underline-offset-auto
+		{
+			Matcher: regexp.MustCompile(`^underline-offset-(auto|\d+|\[.+\])$`),
+			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+				v := pixelValue(match[1])
+				if v == "" {
+					return nil
+				}
+				return &core.CSSEntry{Properties: map[string]string{"text-underline-offset": v}}
+			},
+			Meta: &core.RuleMeta{Layer: "utilities"},
+		},
+
+		// Font style and smoothing
+		staticRule("italic", map[string]string{"font-style": "italic"}),
+		staticRule("not-italic", map[string]string{"font-style": "normal"}),
+		staticRule("antialiased", map[string]string{"-webkit-font-smoothing": "antialiased", "-moz-osx-font-smoothing": "grayscale"}),
+		staticRule("subpixel-antialiased", map[string]string{"-webkit-font-smoothing": "auto", "-moz-osx-font-smoothing": "auto"}),
+
+		// Text transform
+		staticRule("uppercase", map[string]string{"text-transform": "uppercase"}),
+		staticRule("lowercase", map[string]string{"text-transform": "lowercase"}),
+		staticRule("capitalize", map[string]string{"text-transform": "capitalize"}),
+		staticRule("normal-case", map[string]string{"text-transform": "none"}),
+
+		// Text overflow
+		staticRule("truncate", map[string]string{"overflow": "hidden", "text-overflow": "ellipsis", "white-space": "nowrap"}),
+		staticRule("text-ellipsis", map[string]string{"text-overflow": "ellipsis"}),
+		staticRule("text-clip", map[string]string{"text-overflow": "clip"}),
+
+		// Word break
+		staticRule("break-normal", map[string]string{"overflow-wrap": "normal", "word-break": "normal"}),
+		staticRule("break-words", map[string]string{"overflow-wrap": "break-word"}),
+		staticRule("break-all", map[string]string{"word-break": "break-all"}),
+		staticRule("break-keep", map[string]string{"word-break": "keep-all"}),
+
+		// Text decoration line
+		staticRule("underline", map[string]string{"text-decoration-line": "underline"}),
+		staticRule("overline", map[string]string{"text-decoration-line": "overline"}),
+		staticRule("line-through", map[string]string{"text-decoration-line": "line-through"}),
+		staticRule("no-underline", map[string]string{"text-decoration-line": "none"}),
+
+		// Font variant numeric
+		staticRule("normal-nums", map[string]string{"font-variant-numeric": "normal"}),
+		staticRule("ordinal", map[string]string{"--tw-ordinal": "ordinal", "font-variant-numeric": fontVariantNumeric}),
+		staticRule("slashed-zero", map[string]string{"--tw-slashed-zero": "slashed-zero", "font-variant-numeric": fontVariantNumeric}),
+		staticRule("lining-nums", map[string]string{"--tw-numeric-figure": "lining-nums", "font-variant-numeric": fontVariantNumeric}),
+		staticRule("oldstyle-nums", map[string]string{"--tw-numeric-figure": "oldstyle-nums", "font-variant-numeric": fontVariantNumeric}),
+		staticRule("proportional-nums", map[string]string{"--tw-numeric-spacing": "proportional-nums", "font-variant-numeric": fontVariantNumeric}),
+		staticRule("tabular-nums", map[string]string{"--tw-numeric-spacing": "tabular-nums", "font-variant-numeric": fontVariantNumeric}),
+		staticRule("diagonal-fractions", map[string]string{"--tw-numeric-fraction": "diagonal-fractions", "font-variant-numeric": fontVariantNumeric}),
+		staticRule("stacked-fractions", map[string]string{"--tw-numeric-fraction": "stacked-fractions", "font-variant-numeric": fontVariantNumeric}),
+	}
+
+	rules = append(rules, valueRules("text", "text-align", "left", "center", "right", "justify", "start", "end")...)
+	rules = append(rules, valueRules("text", "text-wrap", "wrap", "nowrap", "balance", "pretty")...)
+	rules = append(rules, valueRules("whitespace", "white-space", "normal", "nowrap", "pre", "pre-line", "pre-wrap", "break-spaces")...)
+	rules = append(rules, valueRules("decoration", "text-decoration-style", "solid", "double", "dotted", "dashed", "wavy")...)
+	return rules
+}
+
+var numberRE = regexp.MustCompile(`^-?(\d+\.?\d*|\.\d+)$`)
+
+// lineHeightValue resolve uma altura de linha do tema ("tight"), da escala de
+// espaçamento ("6" -> 1.5rem) ou arbitrária ("[1.1]").
+func lineHeightValue(theme map[string]interface{}, key string) (string, bool) {
+	if v, ok := arbitraryValue(key); ok {
+		return v, true
+	}
+	if v, ok := lookupTheme(theme, "lineHeight", key); ok {
+		return v, true
+	}
+	return spacingValue(theme, key)
+}
+
+// pixelValue converte "2" em "2px"; palavras-chave e valores arbitrários de
+// comprimento são mantidos. Outros valores arbitrários resultam em "".
+func pixelValue(key string) string {
+	if v, ok := arbitraryValue(key); ok {
+		if !isLength(v) {
+			return ""
+		}
+		return strings.TrimPrefix(v, "length:")
+	}
+	if numberRE.MatchString(key) {
+		return key + "px"
+	}
+	switch key {
+	case "auto", "from-font":
+		return key
+	}
+	return ""
+}
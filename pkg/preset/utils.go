@@ -0,0 +1,189 @@
+package preset
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/su3h7am/gocss/pkg/core"
+)
+
+// staticRule cria uma regra estática que gera sempre as mesmas propriedades.
+func staticRule(name string, props map[string]string) core.Rule {
+	return core.Rule{
+		Static: name,
+		Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+			return &core.CSSEntry{Properties: copyProps(props)}
+		},
+		Meta: &core.RuleMeta{Layer: "utilities"},
+	}
+}
+
+// valueRules cria uma regra estática `<prefix>-<valor>` para cada valor de uma
+// propriedade (ex.: "whitespace", "white-space", "nowrap" -> `.whitespace-nowrap`).
+func valueRules(prefix, property string, values ...string) []core.Rule {
+	rules := make([]core.Rule, 0, len(values))
+	for _, v := range values {
+		rules = append(rules, staticRule(prefix+"-"+v, map[string]string{property: v}))
+	}
+	return rules
+}
+
+// mappedRules cria uma regra estática `<prefix>-<nome>` para cada par nome/valor
+// (ex.: "justify", "justify-content", {"between": "space-between"}).
+func mappedRules(prefix, property string, values map[string]string) []core.Rule {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rules := make([]core.Rule, 0, len(values))
+	for _, name := range names {
+		rules = append(rules, staticRule(prefix+"-"+name, map[string]string{property: values[name]}))
+	}
+	return rules
+}
+
+// themeRule cria a regra `<prefix>-<chave>` cujo valor vem de uma seção do tema
+// ou de um valor arbitrário (`<prefix>-[valor]`).
+func themeRule(prefix, section string, properties ...string) core.Rule {
+	return core.Rule{
+		Matcher: regexp.MustCompile(fmt.Sprintf(`^%s-(.+)$`, regexp.QuoteMeta(prefix))),
+		Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+			value, ok := arbitraryValue(match[1])
+			if !ok {
+				if value, ok = lookupTheme(ctx.Theme, section, match[1]); !ok {
+					return nil
+				}
+			}
+			props := make(map[string]string, len(properties))
+			for _, p := range properties {
+				props[p] = value
+			}
+			return &core.CSSEntry{Properties: props}
+		},
+		Meta: &core.RuleMeta{Layer: "utilities"},
+	}
+}
+
+// copyProps copia um mapa de propriedades para que handlers de variantes
+// possam alterá-lo sem afetar a regra original.
+func copyProps(props map[string]string) map[string]string {
+	out := make(map[string]string, len(props))
+	for k, v := range props {
+		out[k] = v
+	}
+	return out
+}
+
+// spacingValue resolve uma chave da escala de espaçamento ("4", "px", "[3px]").
+// Números fora do tema que sejam múltiplos de 0.25 usam a escala padrão de 0.25rem.
+func spacingValue(theme map[string]interface{}, key string) (string, bool) {
+	if v, ok := arbitraryValue(key); ok {
+		return v, true
+	}
+	if v, ok := lookupTheme(theme, "spacing", key); ok {
+		return v, true
+	}
+	n, err := strconv.ParseFloat(key, 64)
+	if err != nil || n < 0 || n*4 != float64(int(n*4)) {
+		return "", false
+	}
+	return formatNumber(n*0.25) + "rem", true
+}
+
+// fractionValue converte uma fração ("1/2") em porcentagem ("50%").
+func fractionValue(key string) (string, bool) {
+	num, den, ok := strings.Cut(key, "/")
+	if !ok {
+		return "", false
+	}
+	n, err1 := strconv.ParseFloat(num, 64)
+	d, err2 := strconv.ParseFloat(den, 64)
+	if err1 != nil || err2 != nil || d == 0 {
+		return "", false
+	}
+	return formatNumber(math.Round(n/d*100*1e6)/1e6) + "%", true
+}
+
+// negate inverte o sinal de um valor CSS, usando calc() quando necessário.
+func negate(v string) string {
+	switch {
+	case v == "0" || v == "0px" || v == "auto":
+		return v
+	case strings.HasPrefix(v, "-"):
+		return v[1:]
+	case strings.HasPrefix(v, "var(") || strings.HasPrefix(v, "calc(") || strings.ContainsAny(v, " "):
+		return "calc(" + v + " * -1)"
+	}
+	return "-" + v
+}
+
+// isLength informa se um valor arbitrário é um comprimento CSS (ex.: "2rem", "calc(...)").
+func isLength(v string) bool {
+	if strings.HasPrefix(v, "length:") {
+		return true
+	}
+	for _, fn := range []string{"calc(", "clamp(", "min(", "max("} {
+		if strings.HasPrefix(v, fn) {
+			return true
+		}
+	}
+	return v == "0" || lengthRE.MatchString(v)
+}
+
+var lengthRE = regexp.MustCompile(`^-?(\d+\.?\d*|\.\d+)(px|r?em|%|v[whib]|[sld]v[wh]|v(min|max)|ch|ex|lh|rlh|cq[whib]|cqmin|cqmax|pt|pc|cm|mm|in|q)$`)
+
+// arbitraryValue extrai o conteúdo de um valor arbitrário (veja core.ArbitraryValue).
+func arbitraryValue(s string) (string, bool) {
+	return core.ArbitraryValue(s)
+}
+
+// splitModifier separa um modificador `/x` do final do valor, ignorando
+// barras dentro de valores arbitrários (ex.: "red-500/50" -> "red-500", "50").
+func splitModifier(s string) (string, string) {
+	depth := 0
+	for i := len(s) - 1; i >= 0; i-- {
+		switch s[i] {
+		case ']', ')':
+			depth++
+		case '[', '(':
+			depth--
+		case '/':
+			if depth == 0 && i > 0 && i < len(s)-1 {
+				return s[:i], s[i+1:]
+			}
+		}
+	}
+	return s, ""
+}
+
+// splitTopLevel divide s pelo separador, ignorando separadores dentro de
+// parênteses ou colchetes (ex.: "0 1px rgb(0, 0, 0), 0 2px red").
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// formatNumber formata um número sem zeros desnecessários (0.5, 1, 0.125).
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,464 @@
+package preset
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/su3h7am/gocss/pkg/core"
+)
+
+// Pesos das variantes (core.Variant.Order): as de maior peso envolvem as
+// demais e vêm depois na saída, como na cascata do Tailwind.
+const (
+	orderPseudoElement = 100
+	orderPseudoClass   = 200 // + posição em pseudoClassList
+	orderAttribute     = 300 // aria-*, data-*, not-*, has-*
+	orderSelector      = 400 // rtl:, ltr:, *:, **:
+	orderRelation      = 500 // group-*, peer-*, in-*
+	orderCondition     = 600 // supports-*, motion-*, print:, ...
+	orderDark          = 700
+	orderContainer     = 800
+	orderBreakpoint    = 900
+)
+
+// pseudoClassList lista as variantes de pseudo-classe na ordem da cascata do
+// Tailwind (ex.: focus depois de hover). A tabela é compartilhada pelas formas
+// not-*, has-*, group-* e peer-*.
+var pseudoClassList = []struct{ name, selector string }{
+	{"first", ":first-child"},
+	{"last", ":last-child"},
+	{"only", ":only-child"},
+	{"odd", ":nth-child(odd)"},
+	{"even", ":nth-child(even)"},
+	{"first-of-type", ":first-of-type"},
+	{"last-of-type", ":last-of-type"},
+	{"only-of-type", ":only-of-type"},
+	{"visited", ":visited"},
+	{"target", ":target"},
+	{"open", "[open]"},
+	{"default", ":default"},
+	{"checked", ":checked"},
+	{"indeterminate", ":indeterminate"},
+	{"placeholder-shown", ":placeholder-shown"},
+	{"autofill", ":autofill"},
+	{"optional", ":optional"},
+	{"required", ":required"},
+	{"valid", ":valid"},
+	{"invalid", ":invalid"},
+	{"user-valid", ":user-valid"},
+	{"user-invalid", ":user-invalid"},
+	{"in-range", ":in-range"},
+	{"out-of-range", ":out-of-range"},
+	{"read-only", ":read-only"},
+	{"read-write", ":read-write"},
+	{"empty", ":empty"},
+	{"focus-within", ":focus-within"},
+	{"hover", ":hover"},
+	{"focus", ":focus"},
+	{"focus-visible", ":focus-visible"},
+	{"active", ":active"},
+	{"enabled", ":enabled"},
+	{"disabled", ":disabled"},
+}
+
+// pseudoClasses indexa pseudoClassList pelo nome da variante.
+var pseudoClasses = func() map[string]string {
+	m := make(map[string]string, len(pseudoClassList))
+	for _, p := range pseudoClassList {
+		m[p.name] = p.selector
+	}
+	return m
+}()
+
+// pseudoElements mapeia as variantes de pseudo-elemento. O pseudo-elemento vai
+// no sufixo do seletor, depois de todas as pseudo-classes.
+var pseudoElements = map[string]string{
+	"before":       "::before",
+	"after":        "::after",
+	"placeholder":  "::placeholder",
+	"file":         "::file-selector-button",
+	"marker":       "::marker",
+	"selection":    "::selection",
+	"first-line":   "::first-line",
+	"first-letter": "::first-letter",
+	"backdrop":     "::backdrop",
+}
+
+var (
+	pseudoVariantRE   = regexp.MustCompile(`^([\w-]+):`)
+	notHasVariantRE   = regexp.MustCompile(`^(not|has)-(\[[^\]]+\]|[\w-]+):`)
+	relationVariantRE = regexp.MustCompile(`^(group|peer|in)-(\[[^\]]+\]|[\w-]+)(?:/([\w-]+))?:`)
+)
+
+// pseudoClass resolve o nome de uma pseudo-classe da tabela ou um seletor arbitrário (`[.is-active]`).
+func pseudoClass(name string) (string, bool) {
+	if v, ok := arbitraryValue(name); ok {
+		return v, true
+	}
+	v, ok := pseudoClasses[name]
+	return v, ok
+}
+
+func getPseudoVariants() []core.Variant {
+	var variants []core.Variant
+	// Pseudo-classes: hover:, focus-visible:, odd:, open:
+	for i, p := range pseudoClassList {
+		prefix, selector := p.name+":", p.selector
+		variants = append(variants, core.Variant{
+			Matcher: func(token string, ctx *core.VariantContext) *core.VariantMatch {
+				if strings.HasPrefix(token, prefix) {
+					return &core.VariantMatch{Matcher: prefix, Value: selector}
+				}
+				return nil
+			},
+			Handler: appendVariant,
+			Order:   orderPseudoClass + i,
+		})
+	}
+
+	return append(variants, []core.Variant{
+		// Pseudo-elementos: before:, after:, placeholder:, file:, marker:
+		{
+			Matcher: func(token string, ctx *core.VariantContext) *core.VariantMatch {
+				m := pseudoVariantRE.FindStringSubmatch(token)
+				if m == nil {
+					return nil
+				}
+				if pseudo, ok := pseudoElements[m[1]]; ok {
+					return &core.VariantMatch{Matcher: m[0], Value: pseudo}
+				}
+				return nil
+			},
+			Handler: func(entry *core.CSSEntry, match *core.VariantMatch) *core.CSSEntry {
+				entry.Suffix = match.Value + entry.Suffix
+				if match.Value == "::before" || match.Value == "::after" {
+					if _, ok := entry.Properties["content"]; !ok {
+						// Cópia: as propriedades podem vir do cache de outro token (atalhos)
+						entry.Properties = copyProps(entry.Properties)
+						entry.Properties["content"] = "var(--tw-content)"
+					}
+				}
+				return entry
+			},
+			Order: orderPseudoElement,
+		},
+		// Ancestral e irmão: group-hover:, group-focus/card:, group-[.is-active]:,
+		// peer-checked:, peer-invalid/email:, in-focus:
+		{
+			Matcher: func(token string, ctx *core.VariantContext) *core.VariantMatch {
+				m := relationVariantRE.FindStringSubmatch(token)
+				if m == nil {
+					return nil
+				}
+				if template, ok := relationTemplate(m[1], m[2], m[3]); ok {
+					return &core.VariantMatch{Matcher: m[0], Value: template}
+				}
+				return nil
+			},
+			Handler: templateVariant,
+			Order:   orderRelation,
+			Wrap:    true,
+		},
+		// Negação e relação: not-hover:, not-[.active]:, has-checked:, has-[img]:
+		{
+			Matcher: func(token string, ctx *core.VariantContext) *core.VariantMatch {
+				m := notHasVariantRE.FindStringSubmatch(token)
+				if m == nil {
+					return nil
+				}
+				if pseudo, ok := pseudoClass(m[2]); ok {
+					return &core.VariantMatch{Matcher: m[0], Value: fmt.Sprintf(":%s(%s)", m[1], pseudo)}
+				}
+				return nil
+			},
+			Handler: appendVariant,
+			Order:   orderAttribute,
+		},
+	}...)
+}
+
+// relationTemplate monta o seletor de uma variante group-*, peer-* ou in-*,
+// com "&" no lugar do seletor do utilitário. Seletores arbitrários podem usar
+// "&" para posicionar o marcador (ex.: `group-[:nth-of-type(3)_&]:`).
+func relationTemplate(kind, name, label string) (string, bool) {
+	pseudo, ok := pseudoClass(name)
+	if !ok {
+		return "", false
+	}
+	if kind == "in" {
+		if label != "" {
+			return "", false
+		}
+		if _, arbitrary := arbitraryValue(name); arbitrary {
+			return ":where(" + pseudo + ") &", true
+		}
+		return ":where(*" + pseudo + ") &", true
+	}
+
+	marker := "." + kind
+	if label != "" {
+		marker = "." + core.EscapeSelector(kind+"/"+label)
+	}
+	combinator := " "
+	if kind == "peer" {
+		combinator = " ~ "
+	}
+	if strings.Contains(pseudo, "&") {
+		return strings.Replace(pseudo, "&", marker, 1) + combinator + "&", true
+	}
+	return marker + pseudo + combinator + "&", true
+}
+
+func getDarkModeVariants(opts *windOptions) []core.Variant {
+	return []core.Variant{
+		// Modo escuro e claro: dark:, light:
+		{
+			Matcher: func(token string, ctx *core.VariantContext) *core.VariantMatch {
+				for _, scheme := range []string{"dark", "light"} {
+					if strings.HasPrefix(token, scheme+":") {
+						return &core.VariantMatch{Matcher: scheme + ":", Value: scheme}
+					}
+				}
+				return nil
+			},
+			Handler: func(entry *core.CSSEntry, match *core.VariantMatch) *core.CSSEntry {
+				media := fmt.Sprintf("@media (prefers-color-scheme: %s)", match.Value)
+				if opts.darkMode == DarkModeMedia {
+					entry.Parent = core.NestParent(media, entry.Parent)
+					return entry
+				}
+
+				selector, opposite := opts.darkSelector, opts.lightSelector
+				if match.Value == "light" {
+					selector, opposite = opposite, selector
+				}
+				if opts.darkMode == DarkModeBoth {
+					// A preferência do sistema não vale sob o seletor do esquema
+					// oposto, para que o tema escolhido na página prevaleça.
+					alt := *entry
+					alt.Selector += fmt.Sprintf(":where(:not(%s, %s *))", opposite, opposite)
+					alt.Parent = core.NestParent(media, entry.Parent)
+					alt.Extra = nil
+					entry.Extra = append(entry.Extra, &alt)
+				}
+				// O seletor vale no próprio elemento ou em qualquer ancestral
+				entry.Selector += fmt.Sprintf(":where(%s, %s *)", selector, selector)
+				return entry
+			},
+			Order: orderDark,
+		},
+	}
+}
+
+// directionVariants anexam a direção do texto ao seletor do utilitário;
+// "&" marca a posição dele.
+var directionVariants = map[string]string{
+	"rtl": `&:where([dir="rtl"], [dir="rtl"] *)`,
+	"ltr": `&:where([dir="ltr"], [dir="ltr"] *)`,
+}
+
+// childVariants envolvem o seletor do utilitário, que passa a selecionar os
+// filhos: `hover:*:` estiliza um filho com hover e `*:hover:` os filhos de
+// um elemento com hover.
+var childVariants = map[string]string{
+	"*":  ":is(& > *)",
+	"**": ":is(& *)",
+}
+
+func getSelectorVariants() []core.Variant {
+	return []core.Variant{
+		// Direção: rtl:, ltr:
+		selectorVariant(directionVariants, false),
+		// Filhos: *: (filhos diretos), **: (todos os descendentes)
+		selectorVariant(childVariants, true),
+	}
+}
+
+// selectorVariant cria a variante `<nome>:` para cada template do mapa.
+func selectorVariant(templates map[string]string, wrap bool) core.Variant {
+	return core.Variant{
+		Matcher: func(token string, ctx *core.VariantContext) *core.VariantMatch {
+			name, _, ok := strings.Cut(token, ":")
+			if !ok {
+				return nil
+			}
+			if template, ok := templates[name]; ok {
+				return &core.VariantMatch{Matcher: name + ":", Value: template}
+			}
+			return nil
+		},
+		Handler: templateVariant,
+		Order:   orderSelector,
+		Wrap:    wrap,
+	}
+}
+
+// appendVariant anexa ao seletor da entrada o seletor resolvido pelo matcher.
+func appendVariant(entry *core.CSSEntry, match *core.VariantMatch) *core.CSSEntry {
+	entry.Selector += match.Value
+	return entry
+}
+
+// templateVariant substitui "&" no template resolvido pelo matcher pelo seletor da entrada.
+func templateVariant(entry *core.CSSEntry, match *core.VariantMatch) *core.CSSEntry {
+	entry.Selector = strings.Replace(match.Value, "&", entry.Selector, 1)
+	return entry
+}
+
+// mediaVariants são variantes de condição fixa, emitidas como parent.
+var mediaVariants = map[string]string{
+	"motion-safe":   "@media (prefers-reduced-motion: no-preference)",
+	"motion-reduce": "@media (prefers-reduced-motion: reduce)",
+	"contrast-more": "@media (prefers-contrast: more)",
+	"contrast-less": "@media (prefers-contrast: less)",
+	"print":         "@media print",
+	"portrait":      "@media (orientation: portrait)",
+	"landscape":     "@media (orientation: landscape)",
+	"forced-colors": "@media (forced-colors: active)",
+	"starting":      "@starting-style",
+}
+
+var (
+	attributeVariantRE = regexp.MustCompile(`^(aria|data)-(\[[^\]]+\]|[\w-]+):`)
+	supportsVariantRE  = regexp.MustCompile(`^supports-(\[[^\]]+\]|[\w-]+):`)
+)
+
+func getConditionVariants() []core.Variant {
+	return []core.Variant{
+		// Media features: motion-safe:, contrast-more:, print:, landscape:, forced-colors:, starting:
+		{
+			Matcher: func(token string, ctx *core.VariantContext) *core.VariantMatch {
+				m := pseudoVariantRE.FindStringSubmatch(token)
+				if m == nil {
+					return nil
+				}
+				if parent, ok := mediaVariants[m[1]]; ok {
+					return &core.VariantMatch{Matcher: m[0], Value: parent}
+				}
+				return nil
+			},
+			Handler: parentVariant,
+			Order:   orderCondition,
+		},
+		// Atributos: aria-checked:, aria-[sort=ascending]:, data-active:, data-[state=open]:.
+		// Os nomes vêm das seções "aria" e "data" do tema; fora delas, aria-foo vira
+		// [aria-foo="true"] e data-foo vira [data-foo].
+		{
+			Matcher: func(token string, ctx *core.VariantContext) *core.VariantMatch {
+				m := attributeVariantRE.FindStringSubmatch(token)
+				if m == nil {
+					return nil
+				}
+				attribute, ok := arbitraryValue(m[2])
+				if !ok {
+					if attribute, ok = lookupTheme(ctx.Theme, m[1], m[2]); !ok {
+						attribute = m[2]
+						if m[1] == "aria" {
+							attribute += `="true"`
+						}
+					}
+				}
+				return &core.VariantMatch{Matcher: m[0], Value: "[" + m[1] + "-" + attribute + "]"}
+			},
+			Handler: appendVariant,
+			Order:   orderAttribute,
+		},
+		// Feature queries: supports-[display:grid]:, supports-[backdrop-filter]:, supports-grid: (tema)
+		{
+			Matcher: func(token string, ctx *core.VariantContext) *core.VariantMatch {
+				m := supportsVariantRE.FindStringSubmatch(token)
+				if m == nil {
+					return nil
+				}
+				condition, ok := arbitraryValue(m[1])
+				if !ok {
+					if condition, ok = lookupTheme(ctx.Theme, "supports", m[1]); !ok {
+						return nil
+					}
+				}
+				return &core.VariantMatch{Matcher: m[0], Value: "@supports " + supportsCondition(condition)}
+			},
+			Handler: parentVariant,
+			Order:   orderCondition,
+		},
+	}
+}
+
+// supportsCondition normaliza a condição de @supports: declarações ganham
+// parênteses e um nome de propriedade isolado é testado com um valor qualquer.
+func supportsCondition(condition string) string {
+	switch {
+	case strings.HasPrefix(condition, "(") || strings.HasPrefix(condition, "not ") || strings.HasPrefix(condition, "selector("):
+		return condition
+	case strings.Contains(condition, ":"):
+		property, value, _ := strings.Cut(condition, ":")
+		return "(" + strings.TrimSpace(property) + ": " + strings.TrimSpace(value) + ")"
+	}
+	return "(" + condition + ": var(--tw))"
+}
+
+// breakpointVariantRE reconhece variantes de breakpoint: sm:, max-md:, min-[800px]:, lt-lg:, at-xl:.
+var breakpointVariantRE = regexp.MustCompile(`^(?:(max|min|lt|at)-)?(\[[^\]]+\]|[\w-]+):`)
+
+// parentVariant aplica a condição resolvida pelo matcher como parent externo da entrada.
+func parentVariant(entry *core.CSSEntry, match *core.VariantMatch) *core.CSSEntry {
+	entry.Parent = core.NestParent(match.Value, entry.Parent)
+	return entry
+}
+
+func getBreakpointVariants() []core.Variant {
+	return []core.Variant{
+		// Breakpoints do tema (seção "screens"), com as formas max-, min-[..],
+		// max-[..] e os intervalos lt- e at- do UnoCSS.
+		{
+			Matcher: func(token string, ctx *core.VariantContext) *core.VariantMatch {
+				m := breakpointVariantRE.FindStringSubmatch(token)
+				if m == nil {
+					return nil
+				}
+				if query, ok := breakpointQuery(ctx.Theme, m[1], m[2]); ok {
+					return &core.VariantMatch{Matcher: m[0], Value: query}
+				}
+				return nil
+			},
+			Handler: parentVariant,
+			Order:   orderBreakpoint,
+		},
+	}
+}
+
+// breakpointQuery monta a media query de um breakpoint com o prefixo dado.
+func breakpointQuery(theme map[string]interface{}, prefix, name string) (string, bool) {
+	if size, ok := arbitraryValue(name); ok {
+		switch prefix {
+		case "min":
+			return fmt.Sprintf("@media (min-width: %s)", size), true
+		case "max":
+			return fmt.Sprintf("@media not all and (min-width: %s)", size), true
+		}
+		return "", false
+	}
+	if prefix == "min" {
+		return "", false
+	}
+
+	points := themeBreakpoints(theme, "screens")
+	for i, bp := range points {
+		if bp.name != name {
+			continue
+		}
+		switch prefix {
+		case "max":
+			return fmt.Sprintf("@media not all and (min-width: %s)", bp.size), true
+		case "lt":
+			return fmt.Sprintf("@media (max-width: calc(%s - 0.1px))", bp.size), true
+		case "at":
+			if i+1 < len(points) {
+				return fmt.Sprintf("@media (min-width: %s) and (max-width: calc(%s - 0.1px))", bp.size, points[i+1].size), true
+			}
+		}
+		return fmt.Sprintf("@media (min-width: %s)", bp.size), true
+	}
+	return "", false
+}
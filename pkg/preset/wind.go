@@ -1,308 +1,141 @@
 package preset
 
 import (
+	_ "embed"
 	"fmt"
 	"regexp"
 	"strconv"
-	"strings"
 
+	"github.com/su3h7am/gocss/pkg/colors"
 	"github.com/su3h7am/gocss/pkg/core"
 )
 
+// Option configura o preset wind.
+type Option func(*windOptions)
+
+type windOptions struct {
+	colorFormat   colors.Format
+	darkMode      DarkModeStrategy
+	darkSelector  string
+	lightSelector string
+}
+
+// DarkModeStrategy define como as variantes dark: e light: são geradas.
+type DarkModeStrategy string
+
+const (
+	// DarkModeMedia usa `@media (prefers-color-scheme: dark)` (padrão).
+	DarkModeMedia DarkModeStrategy = "media"
+	// DarkModeClass usa um seletor no elemento ou em um ancestral (`.dark` por
+	// padrão), para temas alternados pelo servidor ou por script.
+	DarkModeClass DarkModeStrategy = "class"
+	// DarkModeBoth gera as duas formas: o seletor ou a preferência do sistema.
+	// A preferência do sistema é ignorada sob o seletor do esquema oposto.
+	DarkModeBoth DarkModeStrategy = "both"
+)
+
+// WithColorFormat define o formato das cores geradas (hex por padrão).
+func WithColorFormat(format colors.Format) Option {
+	return func(o *windOptions) {
+		o.colorFormat = format
+	}
+}
+
+// WithDarkMode define a estratégia do modo escuro.
+func WithDarkMode(strategy DarkModeStrategy) Option {
+	return func(o *windOptions) {
+		o.darkMode = strategy
+	}
+}
+
+// WithDarkSelector troca os seletores usados pelas estratégias de classe
+// (ex.: "[data-theme=dark]" e "[data-theme=light]").
+func WithDarkSelector(dark, light string) Option {
+	return func(o *windOptions) {
+		o.darkSelector = dark
+		o.lightSelector = light
+	}
+}
+
 // NewWind retorna um preset com regras básicas, similar ao preset-wind.
-func NewWind() core.Preset {
+func NewWind(options ...Option) core.Preset {
+	opts := &windOptions{
+		colorFormat:   colors.FormatHex,
+		darkMode:      DarkModeMedia,
+		darkSelector:  ".dark",
+		lightSelector: ".light",
+	}
+	for _, option := range options {
+		option(opts)
+	}
+
 	return func(config *core.ResolvedConfig) {
+		for k, v := range windTheme() {
+			config.Theme[k] = v
+		}
 		config.Rules = append(config.Rules, getWindRules()...)
-		config.Variants = append(config.Variants, getWindVariants()...)
+		config.Rules = append(config.Rules, getContainerRules()...)
+		config.Rules = append(config.Rules, getPositionRules()...)
+		config.Rules = append(config.Rules, getLayoutRules()...)
+		config.Rules = append(config.Rules, getTypographyRules()...)
+		config.Rules = append(config.Rules, getBorderRules(opts)...)
+		config.Rules = append(config.Rules, getBackgroundRules(opts)...)
+		config.Rules = append(config.Rules, getEffectRules()...)
+		config.Rules = append(config.Rules, getInteractivityRules()...)
+		config.Rules = append(config.Rules, getMotionRules()...)
+		config.Rules = append(config.Rules, getColorRules(opts)...)
+		config.Variants = append(config.Variants, getPseudoVariants()...)
+		config.Variants = append(config.Variants, getSelectorVariants()...)
+		config.Variants = append(config.Variants, getConditionVariants()...)
+		config.Variants = append(config.Variants, getDarkModeVariants(opts)...)
+		config.Variants = append(config.Variants, getBreakpointVariants()...)
+		config.Variants = append(config.Variants, getContainerVariants()...)
+		config.Preflights = append(config.Preflights, propertiesPreflight(numericVars, shadowVars, gradientVars, contentVars, touchVars, snapVars, transformVars, filterVars, backdropVars))
+		if _, ok := config.Layers["properties"]; !ok {
+			config.Layers["properties"] = -1
+		}
 		config.Shortcuts = append(config.Shortcuts, getWindShortcuts()...)
 	}
 }
 
+// rulesData contém as regras declarativas do preset (veja core.RuleDefinition).
+//
+//go:embed rules.yaml
+var rulesData []byte
+
+// getWindRules compila as regras declarativas embutidas em rules.yaml e
+// acrescenta as de margin e padding, que dependem de cálculo.
 func getWindRules() []core.Rule {
-	return []core.Rule{
-		// Base rule for testing layers
-		{
-			Static: "html",
-			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
-				return &core.CSSEntry{
-					Properties: map[string]string{"box-sizing": "border-box"},
-					Selector:   "html",
-				}
-			},
-			Meta: &core.RuleMeta{Layer: "base"},
-		},
-		// Margin
-		{
-			Matcher: regexp.MustCompile(`^m-(\d+)$`),
-			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
-				val, _ := strconv.Atoi(match[1])
-				return &core.CSSEntry{
-					Properties: map[string]string{"margin": fmt.Sprintf("%dpx", val*4)},
-					Selector:   fmt.Sprintf(".%s", ctx.RawSelector),
-				}
-			},
-			Meta: &core.RuleMeta{Layer: "utilities"},
-		},
-		// Padding
-		{
-			Matcher: regexp.MustCompile(`^p-(\d+)$`),
-			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
-				val, _ := strconv.Atoi(match[1])
-				return &core.CSSEntry{
-					Properties: map[string]string{"padding": fmt.Sprintf("%dpx", val*4)},
-					Selector:   fmt.Sprintf(".%s", ctx.RawSelector),
-				}
-			},
-			Meta: &core.RuleMeta{Layer: "utilities"},
-		},
-		{
-			Static: "py-2",
-			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
-				return &core.CSSEntry{
-					Properties: map[string]string{"padding-top": "0.5rem", "padding-bottom": "0.5rem"},
-					Selector:   ".py-2",
-				}
-			},
-			Meta: &core.RuleMeta{Layer: "utilities"},
-		},
-		{
-			Static: "px-4",
-			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
-				return &core.CSSEntry{
-					Properties: map[string]string{"padding-left": "1rem", "padding-right": "1rem"},
-					Selector:   ".px-4",
-				}
-			},
-			Meta: &core.RuleMeta{Layer: "utilities"},
-		},
-		// Display
-		{
-			Static: "block",
-			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
-				return &core.CSSEntry{
-					Properties: map[string]string{"display": "block"},
-					Selector:   ".block",
-				}
-			},
-			Meta: &core.RuleMeta{Layer: "utilities"},
-		},
-		// Colors
-		{
-			Matcher: regexp.MustCompile(`^text-(red|blue|green)-(\d+)$`),
-			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
-				color := match[1]
-				shade := match[2]
-				// Simplified color mapping for now
-				colors := map[string]map[string]string{
-					"red":   {"500": "#ef4444"},
-					"blue":  {"500": "#3b82f6"},
-					"green": {"500": "#22c55e"},
-				}
-				if hex, ok := colors[color][shade]; ok {
-					return &core.CSSEntry{
-						Properties: map[string]string{"color": hex},
-						Selector:   fmt.Sprintf(".%s", ctx.RawSelector),
-					}
-				}
-				return nil
-			},
-			Meta: &core.RuleMeta{Layer: "utilities"},
-		},
-		{
-			Static: "text-white",
-			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
-				return &core.CSSEntry{
-					Properties: map[string]string{"color": "#fff"},
-					Selector:   ".text-white",
-				}
-			},
-			Meta: &core.RuleMeta{Layer: "utilities"},
-		},
-		{
-			Matcher: regexp.MustCompile(`^bg-(red|blue|green)-(\d+)$`),
-			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
-				color := match[1]
-				shade := match[2]
-				// Simplified color mapping for now
-				colors := map[string]map[string]string{
-					"red":   {"500": "#ef4444"},
-					"blue":  {"500": "#3b82f6"},
-					"green": {"500": "#22c55e"},
-				}
-				if hex, ok := colors[color][shade]; ok {
-					return &core.CSSEntry{
-						Properties: map[string]string{"background-color": hex},
-						Selector:   fmt.Sprintf(".%s", ctx.RawSelector),
-					}
-				}
-				return nil
-			},
-			Meta: &core.RuleMeta{Layer: "utilities"},
-		},
-		// Typography
-		{
-			Static: "text-lg",
-			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
-				return &core.CSSEntry{
-					Properties: map[string]string{"font-size": "1.125rem", "line-height": "1.75rem"},
-					Selector:   ".text-lg",
-				}
-			},
-			Meta: &core.RuleMeta{Layer: "utilities"},
-		},
-		{
-			Static: "font-bold",
-			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
-				return &core.CSSEntry{
-					Properties: map[string]string{"font-weight": "700"},
-					Selector:   ".font-bold",
-				}
-			},
-			Meta: &core.RuleMeta{Layer: "utilities"},
-		},
-		// Sizing
-		{
-			Static: "w-full",
-			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
-				return &core.CSSEntry{
-					Properties: map[string]string{"width": "100%"},
-					Selector:   ".w-full",
-				}
-			},
-			Meta: &core.RuleMeta{Layer: "utilities"},
-		},
-		{
-			Static: "h-screen",
-			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
-				return &core.CSSEntry{
-					Properties: map[string]string{"height": "100vh"},
-					Selector:   ".h-screen",
-				}
-			},
-			Meta: &core.RuleMeta{Layer: "utilities"},
-		},
-		// Border Radius
-		{
-			Static: "rounded",
-			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
-				return &core.CSSEntry{
-					Properties: map[string]string{"border-radius": "0.25rem"},
-					Selector:   ".rounded",
-				}
-			},
-			Meta: &core.RuleMeta{Layer: "utilities"},
-		},
-		// Flexbox
-		{
-			Static: "flex",
-			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
-				return &core.CSSEntry{
-					Properties: map[string]string{"display": "flex"},
-					Selector:   ".flex",
-				}
-			},
-			Meta: &core.RuleMeta{Layer: "utilities"},
-		},
-		{
-			Static: "items-center",
-			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
-				return &core.CSSEntry{
-					Properties: map[string]string{"align-items": "center"},
-					Selector:   ".items-center",
-				}
-			},
-			Meta: &core.RuleMeta{Layer: "utilities"},
-		},
-		{
-			Static: "justify-center",
-			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
-				return &core.CSSEntry{
-					Properties: map[string]string{"justify-content": "center"},
-					Selector:   ".justify-center",
-				}
-			},
-			Meta: &core.RuleMeta{Layer: "utilities"},
-		},
-		// Grid
-		{
-			Static: "grid",
-			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
-				return &core.CSSEntry{
-					Properties: map[string]string{"display": "grid"},
-					Selector:   ".grid",
-				}
-			},
-			Meta: &core.RuleMeta{Layer: "utilities"},
-		},
-		{
-			Matcher: regexp.MustCompile(`^grid-cols-(\d+)$`),
-			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
-				cols := match[1]
-				return &core.CSSEntry{
-					Properties: map[string]string{"grid-template-columns": fmt.Sprintf("repeat(%s, minmax(0, 1fr))", cols)},
-					Selector:   fmt.Sprintf(".%s", ctx.RawSelector),
-				}
-			},
-			Meta: &core.RuleMeta{Layer: "utilities"},
-		},
-		// Spacing
-		{
-			Matcher: regexp.MustCompile(`^gap-(\d+)$`),
+	rules, err := core.LoadRulesYAML(rulesData)
+	if err != nil {
+		panic("preset: invalid rules.yaml: " + err.Error())
+	}
+
+	// Margin e padding: m-4, p-13 (múltiplos de 4px)
+	for _, spacing := range []struct{ prefix, property string }{{"m", "margin"}, {"p", "padding"}} {
+		property := spacing.property
+		rules = append(rules, core.Rule{
+			Matcher: regexp.MustCompile(fmt.Sprintf(`^%s-(\d+)$`, spacing.prefix)),
 			Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
 				val, _ := strconv.Atoi(match[1])
-				return &core.CSSEntry{
-					Properties: map[string]string{"gap": fmt.Sprintf("%dpx", val*4)},
-					Selector:   fmt.Sprintf(".%s", ctx.RawSelector),
-				}
+				return &core.CSSEntry{Properties: map[string]string{property: fmt.Sprintf("%dpx", val*4)}}
 			},
 			Meta: &core.RuleMeta{Layer: "utilities"},
-		},
+		})
 	}
+	return rules
 }
 
-func getWindVariants() []core.Variant {
-	return []core.Variant{
-		{
-			Matcher: func(token string, ctx *core.VariantContext) *core.VariantMatch {
-				if strings.HasPrefix(token, "hover:") {
-					return &core.VariantMatch{Matcher: "hover:"}
-				}
-				return nil
-			},
-			Handler: func(entry *core.CSSEntry, match *core.VariantMatch) *core.CSSEntry {
-				entry.Selector = entry.Selector + ":hover"
-				return entry
-			},
-		},
-		{
-			Matcher: func(token string, ctx *core.VariantContext) *core.VariantMatch {
-				if strings.HasPrefix(token, "sm:") {
-					return &core.VariantMatch{Matcher: "sm:"}
-				}
-				return nil
-			},
-			Handler: func(entry *core.CSSEntry, match *core.VariantMatch) *core.CSSEntry {
-				entry.Parent = "@media (min-width: 640px)"
-				return entry
-			},
-		},
-	}
+// windShortcuts são os atalhos do preset; chaves iniciadas por "^" são padrões.
+var windShortcuts = map[string]string{
+	"btn":                    "py-2 px-4 bg-blue-500 text-white font-bold rounded",
+	"^btn-(red|blue|green)$": "bg-$1-500 text-white font-bold rounded",
 }
 
 func getWindShortcuts() []core.Shortcut {
-	return []core.Shortcut{
-		{
-			Static: "btn",
-			Expand: func(match []string) []string {
-				return []string{"py-2", "px-4", "bg-blue-500", "text-white", "font-bold", "rounded"}
-			},
-		},
-		{
-			Pattern: regexp.MustCompile(`^btn-(red|blue|green)$`),
-			Expand: func(match []string) []string {
-				color := match[1]
-				return []string{fmt.Sprintf("bg-%s-500", color), "text-white", "font-bold", "rounded"}
-			},
-		},
+	shortcuts, err := core.ShortcutsFromMap(windShortcuts)
+	if err != nil {
+		panic("preset: invalid shortcut: " + err.Error())
 	}
+	return shortcuts
 }
@@ -0,0 +1,1282 @@
+package preset
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/su3h7am/gocss/pkg/colors"
+	"github.com/su3h7am/gocss/pkg/core"
+	"github.com/su3h7am/gocss/pkg/extractor"
+)
+
+func newTestGenerator() *core.UnoGenerator {
+	return newGenerator(&core.Config{
+		Presets: []core.Preset{NewWind()},
+	})
+}
+
+// newGenerator cria um gerador para uma configuração que deve ser válida.
+func newGenerator(cfg *core.Config) *core.UnoGenerator {
+	resolved, err := core.NewResolvedConfig(cfg)
+	if err != nil {
+		panic(err)
+	}
+	return core.NewGenerator(resolved)
+}
+
+// parse resolve um token e falha o teste se ele não gerar exatamente um utilitário.
+func parse(t *testing.T, g *core.UnoGenerator, token string) *core.StringifiedUtil {
+	t.Helper()
+	utils, err := g.ParseToken(token)
+	if err != nil {
+		t.Fatalf("ParseToken(%q) returned error: %v", token, err)
+	}
+	if len(utils) != 1 {
+		t.Fatalf("ParseToken(%q) returned %d utils, want 1", token, len(utils))
+	}
+	return utils[0]
+}
+
+func TestColorUtilities(t *testing.T) {
+	g := newTestGenerator()
+
+	tests := []struct {
+		token    string
+		selector string
+		entries  map[string]string
+	}{
+		{"text-red-500", ".text-red-500", map[string]string{"color": "#ef4444"}},
+		{"bg-slate-950", ".bg-slate-950", map[string]string{"background-color": "#020617"}},
+		{"text-white", ".text-white", map[string]string{"color": "#ffffff"}},
+		{"bg-red-500/50", `.bg-red-500\/50`, map[string]string{"background-color": "rgb(239 68 68 / 0.5)"}},
+		{"text-black/[0.35]", `.text-black\/\[0\.35\]`, map[string]string{"color": "rgb(0 0 0 / 0.35)"}},
+		{"border-current/25", `.border-current\/25`, map[string]string{"border-color": "color-mix(in srgb, currentColor 25%, transparent)"}},
+		{"bg-[rgb(0_0_255)]/50", `.bg-\[rgb\(0_0_255\)\]\/50`, map[string]string{"background-color": "rgb(0 0 255 / 0.5)"}},
+		{"text-[rebeccapurple]", `.text-\[rebeccapurple\]`, map[string]string{"color": "#663399"}},
+		{"bg-[#123456]", `.bg-\[\#123456\]`, map[string]string{"background-color": "#123456"}},
+		{"ring-blue-500", ".ring-blue-500", map[string]string{"--tw-ring-color": "#3b82f6"}},
+		{"decoration-pink-600", ".decoration-pink-600", map[string]string{"text-decoration-color": "#db2777"}},
+		{"placeholder-gray-400", ".placeholder-gray-400::placeholder", map[string]string{"color": "#9ca3af"}},
+		{"divide-red-500", ".divide-red-500 > :not([hidden]) ~ :not([hidden])", map[string]string{"border-color": "#ef4444"}},
+		{"hover:text-green-500", `.hover\:text-green-500:hover`, map[string]string{"color": "#22c55e"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			util := parse(t, g, tt.token)
+			if util.Selector != tt.selector {
+				t.Errorf("selector = %q, want %q", util.Selector, tt.selector)
+			}
+			if !reflect.DeepEqual(util.Entries, tt.entries) {
+				t.Errorf("entries = %v, want %v", util.Entries, tt.entries)
+			}
+		})
+	}
+
+	for _, token := range []string{"text-unknown-500", "bg-red-1000", "text-[url(a.png)]", "text-inherit/50",
+		"bg-red-500/500", "bg-red-500/[1.5]", "bg-red-500/[-0.5]", "bg-red-500/[150%]", "text-[#fff]/[-10%]"} {
+		if utils, _ := g.ParseToken(token); len(utils) != 0 {
+			t.Errorf("ParseToken(%q) = %v, want no utils", token, utils)
+		}
+	}
+}
+
+func TestColorFormatsAndVariables(t *testing.T) {
+	g := newGenerator(&core.Config{
+		Presets: []core.Preset{NewWind(WithColorFormat(colors.FormatOKLCH))},
+		Theme: map[string]interface{}{
+			"colors": map[string]interface{}{
+				"brand":  "var(--brand)",
+				"accent": "oklch(70% 0.15 200)",
+			},
+		},
+	})
+
+	tests := map[string]string{
+		"text-white":       "oklch(100% 0 0)",
+		"text-black/50":    "oklch(0% 0 0 / 0.5)",
+		"text-brand":       "var(--brand)",
+		"text-brand/50":    "color-mix(in oklab, var(--brand) 50%, transparent)",
+		"text-accent/[.3]": "oklch(70% 0.15 200 / 0.3)",
+	}
+	for token, want := range tests {
+		if got := parse(t, g, token).Entries["color"]; got != want {
+			t.Errorf("%s: color = %q, want %q", token, got, want)
+		}
+	}
+}
+
+func TestTypographyUtilities(t *testing.T) {
+	g := newTestGenerator()
+
+	tests := []struct {
+		token   string
+		entries map[string]string
+	}{
+		{"text-lg", map[string]string{"font-size": "1.125rem", "line-height": "1.75rem"}},
+		{"text-5xl", map[string]string{"font-size": "3rem", "line-height": "1"}},
+		{"text-sm/6", map[string]string{"font-size": "0.875rem", "line-height": "1.5rem"}},
+		{"text-base/tight", map[string]string{"font-size": "1rem", "line-height": "1.25"}},
+		{"text-[2rem]/[1.1]", map[string]string{"font-size": "2rem", "line-height": "1.1"}},
+		{"font-bold", map[string]string{"font-weight": "700"}},
+		{"font-[550]", map[string]string{"font-weight": "550"}},
+		{"font-mono", map[string]string{"font-family": `ui-monospace, SFMono-Regular, Menlo, Monaco, Consolas, "Liberation Mono", "Courier New", monospace`}},
+		{"font-['Inter',_sans-serif]", map[string]string{"font-family": "'Inter', sans-serif"}},
+		{"leading-snug", map[string]string{"line-height": "1.375"}},
+		{"leading-6", map[string]string{"line-height": "1.5rem"}},
+		{"tracking-wide", map[string]string{"letter-spacing": "0.025em"}},
+		{"text-center", map[string]string{"text-align": "center"}},
+		{"uppercase", map[string]string{"text-transform": "uppercase"}},
+		{"truncate", map[string]string{"overflow": "hidden", "text-overflow": "ellipsis", "white-space": "nowrap"}},
+		{"line-clamp-3", map[string]string{"overflow": "hidden", "display": "-webkit-box", "-webkit-box-orient": "vertical", "-webkit-line-clamp": "3"}},
+		{"whitespace-pre-wrap", map[string]string{"white-space": "pre-wrap"}},
+		{"break-words", map[string]string{"overflow-wrap": "break-word"}},
+		{"indent-4", map[string]string{"text-indent": "1rem"}},
+		{"-indent-px", map[string]string{"text-indent": "-1px"}},
+		{"underline", map[string]string{"text-decoration-line": "underline"}},
+		{"decoration-wavy", map[string]string{"text-decoration-style": "wavy"}},
+		{"decoration-2", map[string]string{"text-decoration-thickness": "2px"}},
+		{"underline-offset-4", map[string]string{"text-underline-offset": "4px"}},
+		{"decoration-[3px]", map[string]string{"text-decoration-thickness": "3px"}},
+		{"decoration-[length:var(--w)]", map[string]string{"text-decoration-thickness": "var(--w)"}},
+		{"decoration-[#ff0000]", map[string]string{"text-decoration-color": "#ff0000"}},
+		{"underline-offset-[0.25em]", map[string]string{"text-underline-offset": "0.25em"}},
+		{"tabular-nums", map[string]string{"--tw-numeric-spacing": "tabular-nums", "font-variant-numeric": fontVariantNumeric}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			if got := parse(t, g, tt.token).Entries; !reflect.DeepEqual(got, tt.entries) {
+				t.Errorf("entries = %v, want %v", got, tt.entries)
+			}
+		})
+	}
+}
+
+func TestLayoutUtilities(t *testing.T) {
+	g := newTestGenerator()
+
+	tests := []struct {
+		token   string
+		entries map[string]string
+	}{
+		{"flex-col-reverse", map[string]string{"flex-direction": "column-reverse"}},
+		{"flex-wrap", map[string]string{"flex-wrap": "wrap"}},
+		{"flex-1", map[string]string{"flex": "1 1 0%"}},
+		{"flex-[2_2_0%]", map[string]string{"flex": "2 2 0%"}},
+		{"grow", map[string]string{"flex-grow": "1"}},
+		{"shrink-0", map[string]string{"flex-shrink": "0"}},
+		{"basis-1/3", map[string]string{"flex-basis": "33.333333%"}},
+		{"basis-64", map[string]string{"flex-basis": "16rem"}},
+		{"order-first", map[string]string{"order": "-9999"}},
+		{"-order-2", map[string]string{"order": "-2"}},
+		{"justify-between", map[string]string{"justify-content": "space-between"}},
+		{"justify-items-center", map[string]string{"justify-items": "center"}},
+		{"items-start", map[string]string{"align-items": "flex-start"}},
+		{"content-evenly", map[string]string{"align-content": "space-evenly"}},
+		{"self-end", map[string]string{"align-self": "flex-end"}},
+		{"place-items-center", map[string]string{"place-items": "center"}},
+		{"grid-cols-3", map[string]string{"grid-template-columns": "repeat(3, minmax(0, 1fr))"}},
+		{"grid-rows-subgrid", map[string]string{"grid-template-rows": "subgrid"}},
+		{"grid-cols-[200px_minmax(0,1fr)]", map[string]string{"grid-template-columns": "200px minmax(0,1fr)"}},
+		{"col-span-2", map[string]string{"grid-column": "span 2 / span 2"}},
+		{"col-span-full", map[string]string{"grid-column": "1 / -1"}},
+		{"col-start-2", map[string]string{"grid-column-start": "2"}},
+		{"row-end-auto", map[string]string{"grid-row-end": "auto"}},
+		{"row-span-3", map[string]string{"grid-row": "span 3 / span 3"}},
+		{"col-[1/3]", map[string]string{"grid-column": "1/3"}},
+		{"grid-flow-row-dense", map[string]string{"grid-auto-flow": "row dense"}},
+		{"auto-cols-fr", map[string]string{"grid-auto-columns": "minmax(0, 1fr)"}},
+		{"auto-rows-[minmax(0,2fr)]", map[string]string{"grid-auto-rows": "minmax(0,2fr)"}},
+		{"gap-4", map[string]string{"gap": "1rem"}},
+		{"gap-x-px", map[string]string{"column-gap": "1px"}},
+		{"gap-y-[3px]", map[string]string{"row-gap": "3px"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			if got := parse(t, g, tt.token).Entries; !reflect.DeepEqual(got, tt.entries) {
+				t.Errorf("entries = %v, want %v", got, tt.entries)
+			}
+		})
+	}
+}
+
+func TestBorderUtilities(t *testing.T) {
+	g := newTestGenerator()
+
+	tests := []struct {
+		token    string
+		selector string
+		entries  map[string]string
+	}{
+		{"border", ".border", map[string]string{"border-width": "1px"}},
+		{"border-2", ".border-2", map[string]string{"border-width": "2px"}},
+		{"border-x-4", ".border-x-4", map[string]string{"border-left-width": "4px", "border-right-width": "4px"}},
+		{"border-t", ".border-t", map[string]string{"border-top-width": "1px"}},
+		{"border-s-[3px]", `.border-s-\[3px\]`, map[string]string{"border-inline-start-width": "3px"}},
+		{"border-b-red-500", ".border-b-red-500", map[string]string{"border-bottom-color": "#ef4444"}},
+		{"border-dashed", ".border-dashed", map[string]string{"border-style": "dashed"}},
+		{"border-[#000]", `.border-\[\#000\]`, map[string]string{"border-color": "#000000"}},
+		{"rounded", ".rounded", map[string]string{"border-radius": "0.25rem"}},
+		{"rounded-lg", ".rounded-lg", map[string]string{"border-radius": "0.5rem"}},
+		{"rounded-t-xl", ".rounded-t-xl", map[string]string{"border-top-left-radius": "0.75rem", "border-top-right-radius": "0.75rem"}},
+		{"rounded-br", ".rounded-br", map[string]string{"border-bottom-right-radius": "0.25rem"}},
+		{"rounded-ss-full", ".rounded-ss-full", map[string]string{"border-start-start-radius": "9999px"}},
+		{"ring-2", ".ring-2", map[string]string{
+			"--tw-ring-offset-shadow": ringOffsetShadow,
+			"--tw-ring-shadow":        "var(--tw-ring-inset) 0 0 0 calc(2px + var(--tw-ring-offset-width)) var(--tw-ring-color)",
+			"box-shadow":              ringBoxShadow,
+		}},
+		{"ring-inset", ".ring-inset", map[string]string{"--tw-ring-inset": "inset"}},
+		{"ring-offset-2", ".ring-offset-2", map[string]string{"--tw-ring-offset-width": "2px"}},
+		{"ring-offset-white", ".ring-offset-white", map[string]string{"--tw-ring-offset-color": "#ffffff"}},
+		{"outline-none", ".outline-none", map[string]string{"outline": "2px solid transparent", "outline-offset": "2px"}},
+		{"outline", ".outline", map[string]string{"outline-style": "solid"}},
+		{"outline-dashed", ".outline-dashed", map[string]string{"outline-style": "dashed"}},
+		{"outline-2", ".outline-2", map[string]string{"outline-width": "2px"}},
+		{"outline-offset-4", ".outline-offset-4", map[string]string{"outline-offset": "4px"}},
+		{"-outline-offset-2", ".-outline-offset-2", map[string]string{"outline-offset": "-2px"}},
+		{"divide-x", ".divide-x" + divideSuffix, map[string]string{
+			"--tw-divide-x-reverse": "0",
+			"border-right-width":    "calc(1px * var(--tw-divide-x-reverse))",
+			"border-left-width":     "calc(1px * calc(1 - var(--tw-divide-x-reverse)))",
+		}},
+		{"divide-y-reverse", ".divide-y-reverse" + divideSuffix, map[string]string{"--tw-divide-y-reverse": "1"}},
+		{"divide-dotted", ".divide-dotted" + divideSuffix, map[string]string{"border-style": "dotted"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			util := parse(t, g, tt.token)
+			if util.Selector != tt.selector {
+				t.Errorf("selector = %q, want %q", util.Selector, tt.selector)
+			}
+			if !reflect.DeepEqual(util.Entries, tt.entries) {
+				t.Errorf("entries = %v, want %v", util.Entries, tt.entries)
+			}
+		})
+	}
+}
+
+func TestPositionUtilities(t *testing.T) {
+	g := newTestGenerator()
+
+	tests := []struct {
+		token   string
+		entries map[string]string
+	}{
+		{"absolute", map[string]string{"position": "absolute"}},
+		{"sticky", map[string]string{"position": "sticky"}},
+		{"inset-0", map[string]string{"inset": "0px"}},
+		{"inset-x-4", map[string]string{"left": "1rem", "right": "1rem"}},
+		{"-top-2", map[string]string{"top": "-0.5rem"}},
+		{"left-1/2", map[string]string{"left": "50%"}},
+		{"z-[100]", map[string]string{"z-index": "100"}},
+		{"right-full", map[string]string{"right": "100%"}},
+		{"bottom-auto", map[string]string{"bottom": "auto"}},
+		{"start-px", map[string]string{"inset-inline-start": "1px"}},
+		{"-left-[3px]", map[string]string{"left": "-3px"}},
+		{"z-10", map[string]string{"z-index": "10"}},
+		{"-z-10", map[string]string{"z-index": "-10"}},
+		{"z-auto", map[string]string{"z-index": "auto"}},
+		{"hidden", map[string]string{"display": "none"}},
+		{"inline-flex", map[string]string{"display": "inline-flex"}},
+		{"table-row-group", map[string]string{"display": "table-row-group"}},
+		{"flow-root", map[string]string{"display": "flow-root"}},
+		{"contents", map[string]string{"display": "contents"}},
+		{"overflow-hidden", map[string]string{"overflow": "hidden"}},
+		{"overflow-x-auto", map[string]string{"overflow-x": "auto"}},
+		{"overscroll-y-contain", map[string]string{"overscroll-behavior-y": "contain"}},
+		{"invisible", map[string]string{"visibility": "hidden"}},
+		{"isolate", map[string]string{"isolation": "isolate"}},
+		{"object-cover", map[string]string{"object-fit": "cover"}},
+		{"object-left-top", map[string]string{"object-position": "left top"}},
+		{"aspect-video", map[string]string{"aspect-ratio": "16 / 9"}},
+		{"aspect-4/3", map[string]string{"aspect-ratio": "4 / 3"}},
+		{"aspect-[4/3]", map[string]string{"aspect-ratio": "4/3"}},
+		{"columns-3", map[string]string{"columns": "3"}},
+		{"columns-xs", map[string]string{"columns": "20rem"}},
+		{"float-start", map[string]string{"float": "inline-start"}},
+		{"clear-both", map[string]string{"clear": "both"}},
+		{"box-border", map[string]string{"box-sizing": "border-box"}},
+		{"sr-only", map[string]string{
+			"position": "absolute", "width": "1px", "height": "1px", "padding": "0", "margin": "-1px",
+			"overflow": "hidden", "clip": "rect(0, 0, 0, 0)", "white-space": "nowrap", "border-width": "0",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			if got := parse(t, g, tt.token).Entries; !reflect.DeepEqual(got, tt.entries) {
+				t.Errorf("entries = %v, want %v", got, tt.entries)
+			}
+		})
+	}
+}
+
+func TestInsetOrder(t *testing.T) {
+	g := newGenerator(&core.Config{
+		Presets:    []core.Preset{NewWind()},
+		Extractors: []core.Extractor{&extractor.ExtractorSplit{}},
+	})
+
+	// As shorthands são escritas antes dos lados, que prevalecem sobre elas.
+	tests := [][2]string{
+		{"inset-0", "-top-4"},
+		{"inset-x-0", "-left-2"},
+		{"inset-y-4", "bottom-0"},
+	}
+	for _, tt := range tests {
+		css, err := g.Generate(map[string]string{"index.html": tt[1] + " " + tt[0]})
+		if err != nil {
+			t.Fatal(err)
+		}
+		shorthand := strings.Index(css, "."+tt[0]+" {")
+		side := strings.Index(css, "."+core.EscapeSelector(tt[1])+" {")
+		if shorthand < 0 || side < 0 || shorthand > side {
+			t.Errorf("%s must come before %s in:\n%s", tt[0], tt[1], css)
+		}
+	}
+}
+
+func TestEffectUtilities(t *testing.T) {
+	g := newTestGenerator()
+	filter := composeVars(filterVars)
+	backdrop := composeVars(backdropVars)
+
+	tests := []struct {
+		token   string
+		entries map[string]string
+	}{
+		{"shadow", map[string]string{
+			"--tw-shadow":         "0 1px 3px 0 rgb(0 0 0 / 0.1), 0 1px 2px -1px rgb(0 0 0 / 0.1)",
+			"--tw-shadow-colored": "0 1px 3px 0 var(--tw-shadow-color), 0 1px 2px -1px var(--tw-shadow-color)",
+			"box-shadow":          "var(--tw-ring-offset-shadow, 0 0 #0000), var(--tw-ring-shadow, 0 0 #0000), var(--tw-shadow)",
+		}},
+		{"shadow-none", map[string]string{"--tw-shadow": "0 0 #0000", "--tw-shadow-colored": "0 0 #0000", "box-shadow": shadowBoxShadow}},
+		{"shadow-[0_0_2px]", map[string]string{"--tw-shadow": "0 0 2px", "--tw-shadow-colored": "0 0 2px var(--tw-shadow-color)", "box-shadow": shadowBoxShadow}},
+		{"shadow-[0_0_2px_red]", map[string]string{"--tw-shadow": "0 0 2px red", "--tw-shadow-colored": "0 0 2px var(--tw-shadow-color)", "box-shadow": shadowBoxShadow}},
+		{"shadow-[inset_0_1px_#fff,0_0_2px_rgb(0_0_0/.5)]", map[string]string{
+			"--tw-shadow":         "inset 0 1px #fff,0 0 2px rgb(0 0 0/.5)",
+			"--tw-shadow-colored": "inset 0 1px var(--tw-shadow-color), 0 0 2px var(--tw-shadow-color)",
+			"box-shadow":          shadowBoxShadow,
+		}},
+		{"shadow-red-500/50", map[string]string{"--tw-shadow-color": "rgb(239 68 68 / 0.5)", "--tw-shadow": "var(--tw-shadow-colored)"}},
+		{"opacity-75", map[string]string{"opacity": "0.75"}},
+		{"opacity-[.67]", map[string]string{"opacity": ".67"}},
+		{"mix-blend-multiply", map[string]string{"mix-blend-mode": "multiply"}},
+		{"blur", map[string]string{"--tw-blur": "blur(8px)", "filter": filter}},
+		{"blur-sm", map[string]string{"--tw-blur": "blur(4px)", "filter": filter}},
+		{"brightness-125", map[string]string{"--tw-brightness": "brightness(1.25)", "filter": filter}},
+		{"contrast-50", map[string]string{"--tw-contrast": "contrast(0.5)", "filter": filter}},
+		{"grayscale", map[string]string{"--tw-grayscale": "grayscale(100%)", "filter": filter}},
+		{"grayscale-0", map[string]string{"--tw-grayscale": "grayscale(0)", "filter": filter}},
+		{"-hue-rotate-15", map[string]string{"--tw-hue-rotate": "hue-rotate(-15deg)", "filter": filter}},
+		{"drop-shadow-md", map[string]string{"--tw-drop-shadow": "drop-shadow(0 4px 3px rgb(0 0 0 / 0.07)) drop-shadow(0 2px 2px rgb(0 0 0 / 0.06))", "filter": filter}},
+		{"filter-none", map[string]string{"filter": "none"}},
+		{"backdrop-blur-lg", map[string]string{"--tw-backdrop-blur": "blur(16px)", "-webkit-backdrop-filter": backdrop, "backdrop-filter": backdrop}},
+		{"backdrop-opacity-50", map[string]string{"--tw-backdrop-opacity": "opacity(0.5)", "-webkit-backdrop-filter": backdrop, "backdrop-filter": backdrop}},
+		{"backdrop-sepia", map[string]string{"--tw-backdrop-sepia": "sepia(100%)", "-webkit-backdrop-filter": backdrop, "backdrop-filter": backdrop}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			if got := parse(t, g, tt.token).Entries; !reflect.DeepEqual(got, tt.entries) {
+				t.Errorf("entries = %v, want %v", got, tt.entries)
+			}
+		})
+	}
+}
+
+func TestBackgroundUtilities(t *testing.T) {
+	g := newTestGenerator()
+	stops := "var(--tw-gradient-from), var(--tw-gradient-to)"
+
+	tests := []struct {
+		token   string
+		entries map[string]string
+	}{
+		{"bg-gradient-to-r", map[string]string{"background-image": "linear-gradient(to right, var(--tw-gradient-stops))"}},
+		{"bg-linear-to-tl", map[string]string{"background-image": "linear-gradient(to top left, var(--tw-gradient-stops))"}},
+		{"bg-linear-45", map[string]string{"background-image": "linear-gradient(45deg, var(--tw-gradient-stops))"}},
+		{"-bg-linear-45", map[string]string{"background-image": "linear-gradient(-45deg, var(--tw-gradient-stops))"}},
+		{"bg-radial", map[string]string{"background-image": "radial-gradient(var(--tw-gradient-stops))"}},
+		{"bg-radial-[at_25%_25%]", map[string]string{"background-image": "radial-gradient(at 25% 25%, var(--tw-gradient-stops))"}},
+		{"bg-conic-180", map[string]string{"background-image": "conic-gradient(from 180deg, var(--tw-gradient-stops))"}},
+		{"from-red-500", map[string]string{
+			"--tw-gradient-from":  "#ef4444 var(--tw-gradient-from-position)",
+			"--tw-gradient-to":    "rgb(239 68 68 / 0) var(--tw-gradient-to-position)",
+			"--tw-gradient-stops": stops,
+		}},
+		{"via-white", map[string]string{
+			"--tw-gradient-to":    "rgb(255 255 255 / 0) var(--tw-gradient-to-position)",
+			"--tw-gradient-stops": "var(--tw-gradient-from), #ffffff var(--tw-gradient-via-position), var(--tw-gradient-to)",
+		}},
+		{"to-blue-500/50", map[string]string{"--tw-gradient-to": "rgb(59 130 246 / 0.5) var(--tw-gradient-to-position)"}},
+		{"from-10%", map[string]string{"--tw-gradient-from-position": "10%"}},
+		{"to-[80px]", map[string]string{"--tw-gradient-to-position": "80px"}},
+		{"bg-none", map[string]string{"background-image": "none"}},
+		{"bg-[url(/img/hero.png)]", map[string]string{"background-image": "url(/img/hero.png)"}},
+		{"bg-[length:200px_100px]", map[string]string{"background-size": "200px 100px"}},
+		{"bg-[position:center_top]", map[string]string{"background-position": "center top"}},
+		{"bg-[center_top_1rem]", map[string]string{"background-position": "center top 1rem"}},
+		{"bg-[50%_25%,right_10px_bottom]", map[string]string{"background-position": "50% 25%,right 10px bottom"}},
+		{"bg-[#bada55]", map[string]string{"background-color": "#bada55"}},
+		{"bg-cover", map[string]string{"background-size": "cover"}},
+		{"bg-left-top", map[string]string{"background-position": "left top"}},
+		{"bg-no-repeat", map[string]string{"background-repeat": "no-repeat"}},
+		{"bg-repeat-round", map[string]string{"background-repeat": "round"}},
+		{"bg-fixed", map[string]string{"background-attachment": "fixed"}},
+		{"bg-clip-text", map[string]string{"-webkit-background-clip": "text", "background-clip": "text"}},
+		{"bg-origin-padding", map[string]string{"background-origin": "padding-box"}},
+		{"bg-blend-multiply", map[string]string{"background-blend-mode": "multiply"}},
+		{"bg-red-500", map[string]string{"background-color": "#ef4444"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			if got := parse(t, g, tt.token).Entries; !reflect.DeepEqual(got, tt.entries) {
+				t.Errorf("entries = %v, want %v", got, tt.entries)
+			}
+		})
+	}
+}
+
+func TestInteractivityUtilities(t *testing.T) {
+	g := newTestGenerator()
+	touch := "var(--tw-pan-x) var(--tw-pan-y) var(--tw-pinch-zoom)"
+
+	tests := []struct {
+		token   string
+		entries map[string]string
+	}{
+		{"cursor-pointer", map[string]string{"cursor": "pointer"}},
+		{"cursor-not-allowed", map[string]string{"cursor": "not-allowed"}},
+		{"cursor-[url(hand.cur),_pointer]", map[string]string{"cursor": "url(hand.cur), pointer"}},
+		{"pointer-events-none", map[string]string{"pointer-events": "none"}},
+		{"select-none", map[string]string{"-webkit-user-select": "none", "user-select": "none"}},
+		{"resize", map[string]string{"resize": "both"}},
+		{"resize-y", map[string]string{"resize": "vertical"}},
+		{"scroll-smooth", map[string]string{"scroll-behavior": "smooth"}},
+		{"snap-x", map[string]string{"scroll-snap-type": "x var(--tw-scroll-snap-strictness)"}},
+		{"snap-mandatory", map[string]string{"--tw-scroll-snap-strictness": "mandatory"}},
+		{"snap-center", map[string]string{"scroll-snap-align": "center"}},
+		{"snap-align-none", map[string]string{"scroll-snap-align": "none"}},
+		{"snap-always", map[string]string{"scroll-snap-stop": "always"}},
+		{"touch-none", map[string]string{"touch-action": "none"}},
+		{"touch-pan-left", map[string]string{"--tw-pan-x": "pan-left", "touch-action": touch}},
+		{"touch-pinch-zoom", map[string]string{"--tw-pinch-zoom": "pinch-zoom", "touch-action": touch}},
+		{"will-change-scroll", map[string]string{"will-change": "scroll-position"}},
+		{"will-change-[top,left]", map[string]string{"will-change": "top,left"}},
+		{"appearance-none", map[string]string{"appearance": "none"}},
+		{"accent-auto", map[string]string{"accent-color": "auto"}},
+		{"accent-pink-500", map[string]string{"accent-color": "#ec4899"}},
+		{"caret-blue-500", map[string]string{"caret-color": "#3b82f6"}},
+		{"fill-none", map[string]string{"fill": "none"}},
+		{"fill-current", map[string]string{"fill": "currentColor"}},
+		{"stroke-2", map[string]string{"stroke-width": "2"}},
+		{"stroke-[3px]", map[string]string{"stroke-width": "3px"}},
+		{"stroke-[#243c5a]", map[string]string{"stroke": "#243c5a"}},
+		{"border-collapse", map[string]string{"border-collapse": "collapse"}},
+		{"table-fixed", map[string]string{"table-layout": "fixed"}},
+		{"forced-color-adjust-none", map[string]string{"forced-color-adjust": "none"}},
+		{"sr-only", map[string]string{
+			"position": "absolute", "width": "1px", "height": "1px", "padding": "0", "margin": "-1px",
+			"overflow": "hidden", "clip": "rect(0, 0, 0, 0)", "white-space": "nowrap", "border-width": "0",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			if got := parse(t, g, tt.token).Entries; !reflect.DeepEqual(got, tt.entries) {
+				t.Errorf("entries = %v, want %v", got, tt.entries)
+			}
+		})
+	}
+}
+
+func TestPropertiesPreflight(t *testing.T) {
+	g := newGenerator(&core.Config{
+		Presets:    []core.Preset{NewWind()},
+		Extractors: []core.Extractor{&extractor.ExtractorSplit{}},
+	})
+	css, err := g.Generate(map[string]string{"index.html": "blur"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(css, "@layer properties {\n  *, ::before, ::after, ::backdrop {\n") {
+		t.Errorf("Expected properties layer first, got:\n%s", css)
+	}
+	for _, want := range []string{"    --tw-blur: ;\n", "    --tw-ring-offset-width: 0px;\n", "--tw-blur: blur(8px);"} {
+		if strings.Count(css, want) != 1 {
+			t.Errorf("Expected %q exactly once in:\n%s", want, css)
+		}
+	}
+}
+
+func TestMotionUtilities(t *testing.T) {
+	g := newTestGenerator()
+
+	tests := []struct {
+		token   string
+		entries map[string]string
+	}{
+		{"translate-x-4", map[string]string{"--tw-translate-x": "1rem", "transform": transform}},
+		{"-translate-y-1/2", map[string]string{"--tw-translate-y": "-50%", "transform": transform}},
+		{"translate-z-[10px]", map[string]string{"--tw-translate-z": "10px", "transform": transform}},
+		{"translate-full", map[string]string{"--tw-translate-x": "100%", "--tw-translate-y": "100%", "transform": transform}},
+		{"rotate-45", map[string]string{"--tw-rotate": "45deg", "transform": transform}},
+		{"-rotate-x-12", map[string]string{"--tw-rotate-x": "-12deg", "transform": transform}},
+		{"scale-95", map[string]string{"--tw-scale-x": "0.95", "--tw-scale-y": "0.95", "transform": transform}},
+		{"-scale-x-100", map[string]string{"--tw-scale-x": "-1", "transform": transform}},
+		{"skew-y-3", map[string]string{"--tw-skew-y": "3deg", "transform": transform}},
+		{"transform-none", map[string]string{"transform": "none"}},
+		{"perspective-near", map[string]string{"perspective": "300px"}},
+		{"origin-top-left", map[string]string{"transform-origin": "top left"}},
+		{"origin-[33%_75%]", map[string]string{"transform-origin": "33% 75%"}},
+		{"transition", map[string]string{
+			"transition-property":        "color, background-color, border-color, text-decoration-color, fill, stroke, opacity, box-shadow, transform, filter, backdrop-filter",
+			"transition-timing-function": "cubic-bezier(0.4, 0, 0.2, 1)",
+			"transition-duration":        "150ms",
+		}},
+		{"transition-opacity", map[string]string{
+			"transition-property":        "opacity",
+			"transition-timing-function": "cubic-bezier(0.4, 0, 0.2, 1)",
+			"transition-duration":        "150ms",
+		}},
+		{"transition-[height,margin]", map[string]string{
+			"transition-property":        "height, margin",
+			"transition-timing-function": "cubic-bezier(0.4, 0, 0.2, 1)",
+			"transition-duration":        "150ms",
+		}},
+		{"transition-none", map[string]string{"transition-property": "none"}},
+		{"duration-300", map[string]string{"transition-duration": "300ms"}},
+		{"delay-[2s]", map[string]string{"transition-delay": "2s"}},
+		{"ease-in-out", map[string]string{"transition-timing-function": "cubic-bezier(0.4, 0, 0.2, 1)"}},
+		{"animate-none", map[string]string{"animation": "none"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			if got := parse(t, g, tt.token).Entries; !reflect.DeepEqual(got, tt.entries) {
+				t.Errorf("entries = %v, want %v", got, tt.entries)
+			}
+		})
+	}
+
+	// Uma animação arbitrária vazia não gera CSS (e não deve causar pânico)
+	if utils, err := g.ParseToken("animate-[_]"); err != nil || len(utils) != 0 {
+		t.Errorf("animate-[_] = %v, %v; want no utils", utils, err)
+	}
+}
+
+func TestAnimationKeyframes(t *testing.T) {
+	g := newGenerator(&core.Config{
+		Presets:    []core.Preset{NewWind()},
+		Extractors: []core.Extractor{&extractor.ExtractorSplit{}},
+		Theme: map[string]interface{}{
+			"animation": map[string]interface{}{"wiggle": "wiggle 1s ease-in-out infinite"},
+			"keyframes": map[string]interface{}{"wiggle": "0%, 100% {\n  transform: rotate(-3deg);\n}\n50% {\n  transform: rotate(3deg);\n}"},
+		},
+	})
+
+	css, err := g.Generate(map[string]string{"index.html": "animate-spin hover:animate-spin animate-wiggle"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	spin := "  @keyframes spin {\n    to {\n      transform: rotate(360deg);\n    }\n  }\n"
+	if strings.Count(css, spin) != 1 {
+		t.Errorf("Expected spin keyframes exactly once in:\n%s", css)
+	}
+	if !strings.Contains(css, "  @keyframes wiggle {\n    0%, 100% {\n      transform: rotate(-3deg);\n    }\n") {
+		t.Errorf("Expected custom wiggle keyframes in:\n%s", css)
+	}
+	if strings.Contains(css, "@keyframes ping") || strings.Contains(css, "@keyframes bounce") {
+		t.Errorf("Expected unused keyframes to be omitted from:\n%s", css)
+	}
+}
+
+func TestContainerUtility(t *testing.T) {
+	utils, err := newTestGenerator().ParseToken("container")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []core.StringifiedUtil{
+		{Selector: ".container", Entries: map[string]string{"width": "100%"}},
+		{Selector: ".container", Entries: map[string]string{"max-width": "640px"}, Parent: "@media (min-width: 640px)"},
+		{Selector: ".container", Entries: map[string]string{"max-width": "768px"}, Parent: "@media (min-width: 768px)"},
+		{Selector: ".container", Entries: map[string]string{"max-width": "1024px"}, Parent: "@media (min-width: 1024px)"},
+		{Selector: ".container", Entries: map[string]string{"max-width": "1280px"}, Parent: "@media (min-width: 1280px)"},
+		{Selector: ".container", Entries: map[string]string{"max-width": "1536px"}, Parent: "@media (min-width: 1536px)"},
+	}
+	if len(utils) != len(expected) {
+		t.Fatalf("got %d utils, want %d", len(utils), len(expected))
+	}
+	for i, util := range utils {
+		if util.Selector != expected[i].Selector || util.Parent != expected[i].Parent || !reflect.DeepEqual(util.Entries, expected[i].Entries) {
+			t.Errorf("util %d = %+v, want %+v", i, *util, expected[i])
+		}
+	}
+
+	g := newGenerator(&core.Config{
+		Presets: []core.Preset{NewWind()},
+		Theme: map[string]interface{}{
+			"screens": map[string]interface{}{"md": "48rem", "wide": "1440px"},
+			"container": map[string]interface{}{
+				"center":  true,
+				"padding": map[string]interface{}{"DEFAULT": "1rem", "wide": "4rem"},
+			},
+		},
+	})
+	utils, err = g.ParseToken("container")
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := map[string]string{"width": "100%", "margin-left": "auto", "margin-right": "auto", "padding-left": "1rem", "padding-right": "1rem"}
+	if !reflect.DeepEqual(utils[0].Entries, base) {
+		t.Errorf("base entries = %v, want %v", utils[0].Entries, base)
+	}
+	// As telas do usuário estendem as padrão e continuam ordenadas por largura
+	var parents []string
+	for _, util := range utils[1:] {
+		parents = append(parents, util.Parent)
+	}
+	wantParents := []string{"640px", "48rem", "1024px", "1280px", "1440px", "1536px"}
+	for i, size := range wantParents {
+		wantParents[i] = "@media (min-width: " + size + ")"
+	}
+	if !reflect.DeepEqual(parents, wantParents) {
+		t.Errorf("parents = %v, want %v", parents, wantParents)
+	}
+	wide := map[string]string{"max-width": "1440px", "padding-left": "4rem", "padding-right": "4rem"}
+	if got := utils[5].Entries; !reflect.DeepEqual(got, wide) {
+		t.Errorf("wide entries = %v, want %v", got, wide)
+	}
+
+	// Com uma variante de largura, o bloco do próprio breakpoint se funde ao da
+	// variante e os aninhados seguem ordenados por largura.
+	g = newGenerator(&core.Config{
+		Presets:    []core.Preset{NewWind()},
+		Extractors: []core.Extractor{&extractor.ExtractorSplit{}},
+	})
+	css, err := g.Generate(map[string]string{"index.html": "md:container"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	merged := "  @media (min-width: 768px) {\n    .md\\:container {\n      width: 100%;\n      max-width: 768px;\n    }\n  }\n"
+	if !strings.Contains(css, merged) {
+		t.Errorf("Expected merged 768px block in:\n%s", css)
+	}
+	if n := strings.Count(css, ".md\\:container {"); n != 5 {
+		t.Errorf("got %d .md\\:container rules, want 5:\n%s", n, css)
+	}
+	last := -1
+	for _, size := range []string{"640px", "768px", "1024px", "1280px", "1536px"} {
+		i := strings.Index(css, "max-width: "+size+";")
+		if i < last {
+			t.Errorf("max-width: %s is out of order in:\n%s", size, css)
+		}
+		last = i
+	}
+}
+
+func TestContainerQueries(t *testing.T) {
+	g := newTestGenerator()
+
+	tests := []struct {
+		token    string
+		selector string
+		parent   string
+		entries  map[string]string
+	}{
+		{"@container", `.\@container`, "", map[string]string{"container-type": "inline-size"}},
+		{"@container/sidebar", `.\@container\/sidebar`, "", map[string]string{"container-type": "inline-size", "container-name": "sidebar"}},
+		{"@container-normal", `.\@container-normal`, "", map[string]string{"container-type": "normal"}},
+		{"@md:flex", `.\@md\:flex`, "@container (min-width: 28rem)", map[string]string{"display": "flex"}},
+		{"@[400px]:flex", `.\@\[400px\]\:flex`, "@container (min-width: 400px)", map[string]string{"display": "flex"}},
+		{"@lg/sidebar:flex", `.\@lg\/sidebar\:flex`, "@container sidebar (min-width: 32rem)", map[string]string{"display": "flex"}},
+		{"sm:@lg:flex", `.sm\:\@lg\:flex`, "@media (min-width: 640px) $$ @container (min-width: 32rem)", map[string]string{"display": "flex"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			util := parse(t, g, tt.token)
+			if util.Selector != tt.selector {
+				t.Errorf("selector = %q, want %q", util.Selector, tt.selector)
+			}
+			if util.Parent != tt.parent {
+				t.Errorf("parent = %q, want %q", util.Parent, tt.parent)
+			}
+			if !reflect.DeepEqual(util.Entries, tt.entries) {
+				t.Errorf("entries = %v, want %v", util.Entries, tt.entries)
+			}
+		})
+	}
+
+	if utils, _ := g.ParseToken("@huge:flex"); len(utils) != 0 {
+		t.Errorf("ParseToken(%q) = %v, want no utils", "@huge:flex", utils)
+	}
+}
+
+func TestBreakpointVariants(t *testing.T) {
+	g := newTestGenerator()
+
+	tests := []struct {
+		token    string
+		selector string
+		parent   string
+	}{
+		{"sm:flex", `.sm\:flex`, "@media (min-width: 640px)"},
+		{"2xl:flex", `.\32 xl\:flex`, "@media (min-width: 1536px)"},
+		{"max-md:flex", `.max-md\:flex`, "@media not all and (min-width: 768px)"},
+		{"min-[800px]:flex", `.min-\[800px\]\:flex`, "@media (min-width: 800px)"},
+		{"max-[600px]:flex", `.max-\[600px\]\:flex`, "@media not all and (min-width: 600px)"},
+		{"lt-lg:flex", `.lt-lg\:flex`, "@media (max-width: calc(1024px - 0.1px))"},
+		{"at-md:flex", `.at-md\:flex`, "@media (min-width: 768px) and (max-width: calc(1024px - 0.1px))"},
+		{"at-2xl:flex", `.at-2xl\:flex`, "@media (min-width: 1536px)"},
+		{"md:max-xl:flex", `.md\:max-xl\:flex`, "@media (min-width: 768px) $$ @media not all and (min-width: 1280px)"},
+		{"md:hover:flex", `.md\:hover\:flex:hover`, "@media (min-width: 768px)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			util := parse(t, g, tt.token)
+			if util.Selector != tt.selector {
+				t.Errorf("selector = %q, want %q", util.Selector, tt.selector)
+			}
+			if util.Parent != tt.parent {
+				t.Errorf("parent = %q, want %q", util.Parent, tt.parent)
+			}
+		})
+	}
+
+	for _, token := range []string{"tablet:flex", "min-md:flex", "lt-[600px]:flex"} {
+		if utils, _ := g.ParseToken(token); len(utils) != 0 {
+			t.Errorf("ParseToken(%q) = %v, want no utils", token, utils)
+		}
+	}
+
+	custom := newGenerator(&core.Config{
+		Presets: []core.Preset{NewWind()},
+		Theme:   map[string]interface{}{"screens": map[string]interface{}{"tablet": "40rem"}},
+	})
+	if util := parse(t, custom, "tablet:flex"); util.Parent != "@media (min-width: 40rem)" {
+		t.Errorf("parent = %q, want custom tablet breakpoint", util.Parent)
+	}
+}
+
+func TestBreakpointOrder(t *testing.T) {
+	g := newGenerator(&core.Config{
+		Presets:    []core.Preset{NewWind()},
+		Extractors: []core.Extractor{&extractor.ExtractorSplit{}},
+	})
+
+	css, err := g.Generate(map[string]string{"index.html": "xl:flex sm:flex max-sm:flex flex md:flex max-lg:flex"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := []string{
+		".flex {",
+		"@media not all and (min-width: 1024px)",
+		"@media not all and (min-width: 640px)",
+		"@media (min-width: 640px)",
+		"@media (min-width: 768px)",
+		"@media (min-width: 1280px)",
+	}
+	last := -1
+	for _, s := range order {
+		i := strings.Index(css, s)
+		if i <= last {
+			t.Fatalf("Expected %q after previous entries in:\n%s", s, css)
+		}
+		last = i
+	}
+}
+
+func TestPseudoVariants(t *testing.T) {
+	g := newTestGenerator()
+
+	tests := []struct {
+		token    string
+		selector string
+		entries  map[string]string
+	}{
+		{"hover:flex", `.hover\:flex:hover`, map[string]string{"display": "flex"}},
+		{"focus-visible:flex", `.focus-visible\:flex:focus-visible`, map[string]string{"display": "flex"}},
+		{"first:flex", `.first\:flex:first-child`, map[string]string{"display": "flex"}},
+		{"odd:flex", `.odd\:flex:nth-child(odd)`, map[string]string{"display": "flex"}},
+		{"placeholder-shown:flex", `.placeholder-shown\:flex:placeholder-shown`, map[string]string{"display": "flex"}},
+		{"open:flex", `.open\:flex[open]`, map[string]string{"display": "flex"}},
+		{"before:block", `.before\:block::before`, map[string]string{"display": "block", "content": "var(--tw-content)"}},
+		{"after:content-['*']", `.after\:content-\[\'\*\'\]::after`, map[string]string{"--tw-content": "'*'", "content": "var(--tw-content)"}},
+		{"hover:before:block", `.hover\:before\:block:hover::before`, map[string]string{"display": "block", "content": "var(--tw-content)"}},
+		{"placeholder:italic", `.placeholder\:italic::placeholder`, map[string]string{"font-style": "italic"}},
+		{"file:border-0", `.file\:border-0::file-selector-button`, map[string]string{"border-width": "0px"}},
+		{"marker:text-red-500", `.marker\:text-red-500::marker`, map[string]string{"color": "#ef4444"}},
+		{"selection:bg-white", `.selection\:bg-white::selection`, map[string]string{"background-color": "#ffffff"}},
+		{"first-letter:uppercase", `.first-letter\:uppercase::first-letter`, map[string]string{"text-transform": "uppercase"}},
+		{"backdrop:opacity-50", `.backdrop\:opacity-50::backdrop`, map[string]string{"opacity": "0.5"}},
+		{"not-first:flex", `.not-first\:flex:not(:first-child)`, map[string]string{"display": "flex"}},
+		{"not-[.active]:flex", `.not-\[\.active\]\:flex:not(.active)`, map[string]string{"display": "flex"}},
+		{"has-checked:flex", `.has-checked\:flex:has(:checked)`, map[string]string{"display": "flex"}},
+		{"has-[img]:flex", `.has-\[img\]\:flex:has(img)`, map[string]string{"display": "flex"}},
+		{"has-[>_img]:flex", `.has-\[\>_img\]\:flex:has(> img)`, map[string]string{"display": "flex"}},
+		{"content-none", `.content-none`, map[string]string{"--tw-content": "none", "content": "var(--tw-content)"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			util := parse(t, g, tt.token)
+			if util.Selector != tt.selector {
+				t.Errorf("selector = %q, want %q", util.Selector, tt.selector)
+			}
+			if !reflect.DeepEqual(util.Entries, tt.entries) {
+				t.Errorf("entries = %v, want %v", util.Entries, tt.entries)
+			}
+		})
+	}
+
+	for _, token := range []string{"unknown:flex", "not-before:flex", "has-unknown:flex"} {
+		if utils, _ := g.ParseToken(token); len(utils) != 0 {
+			t.Errorf("ParseToken(%q) = %v, want no utils", token, utils)
+		}
+	}
+}
+
+func TestGroupAndPeerVariants(t *testing.T) {
+	g := newTestGenerator()
+
+	tests := []struct {
+		token    string
+		selector string
+	}{
+		{"group-hover:flex", `.group:hover .group-hover\:flex`},
+		{"group-focus-within:flex", `.group:focus-within .group-focus-within\:flex`},
+		{"group-open:flex", `.group[open] .group-open\:flex`},
+		{"group-hover/card:flex", `.group\/card:hover .group-hover\/card\:flex`},
+		{"group-[.is-active]:flex", `.group.is-active .group-\[\.is-active\]\:flex`},
+		{"group-[:nth-of-type(3)_&]:flex", `:nth-of-type(3) .group .group-\[\:nth-of-type\(3\)_\&\]\:flex`},
+		{"peer-checked:flex", `.peer:checked ~ .peer-checked\:flex`},
+		{"peer-invalid/email:flex", `.peer\/email:invalid ~ .peer-invalid\/email\:flex`},
+		{"peer-[.is-dirty]:flex", `.peer.is-dirty ~ .peer-\[\.is-dirty\]\:flex`},
+		{"in-focus:flex", `:where(*:focus) .in-focus\:flex`},
+		{"in-[.sidebar]:flex", `:where(.sidebar) .in-\[\.sidebar\]\:flex`},
+		{"group-hover:hover:flex", `.group:hover .group-hover\:hover\:flex:hover`},
+		{"group-hover:before:block", `.group:hover .group-hover\:before\:block::before`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			if util := parse(t, g, tt.token); util.Selector != tt.selector {
+				t.Errorf("selector = %q, want %q", util.Selector, tt.selector)
+			}
+		})
+	}
+
+	if util := parse(t, g, "md:group-hover:flex"); util.Parent != "@media (min-width: 768px)" {
+		t.Errorf("parent = %q, want md breakpoint", util.Parent)
+	}
+	for _, token := range []string{"group-unknown:flex", "in-hover/card:flex"} {
+		if utils, _ := g.ParseToken(token); len(utils) != 0 {
+			t.Errorf("ParseToken(%q) = %v, want no utils", token, utils)
+		}
+	}
+}
+
+func TestDarkModeVariants(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+		token   string
+		want    []core.StringifiedUtil
+	}{
+		{"media", nil, "dark:flex", []core.StringifiedUtil{
+			{Selector: `.dark\:flex`, Parent: "@media (prefers-color-scheme: dark)"},
+		}},
+		{"media light", nil, "light:flex", []core.StringifiedUtil{
+			{Selector: `.light\:flex`, Parent: "@media (prefers-color-scheme: light)"},
+		}},
+		{"class", []Option{WithDarkMode(DarkModeClass)}, "dark:hover:flex", []core.StringifiedUtil{
+			{Selector: `.dark\:hover\:flex:hover:where(.dark, .dark *)`},
+		}},
+		{"attribute selector", []Option{WithDarkMode(DarkModeClass), WithDarkSelector("[data-theme=dark]", "[data-theme=light]")}, "light:flex", []core.StringifiedUtil{
+			{Selector: `.light\:flex:where([data-theme=light], [data-theme=light] *)`},
+		}},
+		{"both", []Option{WithDarkMode(DarkModeBoth)}, "md:dark:flex", []core.StringifiedUtil{
+			{Selector: `.md\:dark\:flex:where(.dark, .dark *)`, Parent: "@media (min-width: 768px)"},
+			{Selector: `.md\:dark\:flex:where(:not(.light, .light *))`, Parent: "@media (min-width: 768px) $$ @media (prefers-color-scheme: dark)"},
+		}},
+		{"both light", []Option{WithDarkMode(DarkModeBoth), WithDarkSelector("[data-theme=dark]", "[data-theme=light]")}, "light:flex", []core.StringifiedUtil{
+			{Selector: `.light\:flex:where([data-theme=light], [data-theme=light] *)`},
+			{Selector: `.light\:flex:where(:not([data-theme=dark], [data-theme=dark] *))`, Parent: "@media (prefers-color-scheme: light)"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newGenerator(&core.Config{
+				Presets: []core.Preset{NewWind(tt.options...)},
+			})
+			utils, err := g.ParseToken(tt.token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(utils) != len(tt.want) {
+				t.Fatalf("got %d utils, want %d", len(utils), len(tt.want))
+			}
+			for i, util := range utils {
+				if util.Selector != tt.want[i].Selector || util.Parent != tt.want[i].Parent {
+					t.Errorf("util %d = %q in %q, want %q in %q", i, util.Selector, util.Parent, tt.want[i].Selector, tt.want[i].Parent)
+				}
+				if !reflect.DeepEqual(util.Entries, map[string]string{"display": "flex"}) {
+					t.Errorf("util %d entries = %v", i, util.Entries)
+				}
+			}
+		})
+	}
+}
+
+func TestConditionVariants(t *testing.T) {
+	g := newGenerator(&core.Config{
+		Presets: []core.Preset{NewWind()},
+		Theme: map[string]interface{}{
+			"aria":     map[string]interface{}{"invalid": `invalid="true"`},
+			"data":     map[string]interface{}{"checked": `ui~="checked"`},
+			"supports": map[string]interface{}{"grid": "display: grid"},
+		},
+	})
+
+	tests := []struct {
+		token    string
+		selector string
+		parent   string
+	}{
+		{"aria-checked:flex", `.aria-checked\:flex[aria-checked="true"]`, ""},
+		{"aria-invalid:flex", `.aria-invalid\:flex[aria-invalid="true"]`, ""},
+		{"aria-[sort=ascending]:flex", `.aria-\[sort\=ascending\]\:flex[aria-sort=ascending]`, ""},
+		{"data-active:flex", `.data-active\:flex[data-active]`, ""},
+		{"data-checked:flex", `.data-checked\:flex[data-ui~="checked"]`, ""},
+		{"data-[state=open]:flex", `.data-\[state\=open\]\:flex[data-state=open]`, ""},
+		{"supports-[display:grid]:grid", `.supports-\[display\:grid\]\:grid`, "@supports (display: grid)"},
+		{"supports-[backdrop-filter]:flex", `.supports-\[backdrop-filter\]\:flex`, "@supports (backdrop-filter: var(--tw))"},
+		{"supports-grid:grid", `.supports-grid\:grid`, "@supports (display: grid)"},
+		{"motion-safe:flex", `.motion-safe\:flex`, "@media (prefers-reduced-motion: no-preference)"},
+		{"motion-reduce:flex", `.motion-reduce\:flex`, "@media (prefers-reduced-motion: reduce)"},
+		{"contrast-more:flex", `.contrast-more\:flex`, "@media (prefers-contrast: more)"},
+		{"print:hidden", `.print\:hidden`, "@media print"},
+		{"portrait:flex", `.portrait\:flex`, "@media (orientation: portrait)"},
+		{"landscape:flex", `.landscape\:flex`, "@media (orientation: landscape)"},
+		{"forced-colors:flex", `.forced-colors\:flex`, "@media (forced-colors: active)"},
+		{"starting:opacity-0", `.starting\:opacity-0`, "@starting-style"},
+		{"md:aria-expanded:hover:flex", `.md\:aria-expanded\:hover\:flex:hover[aria-expanded="true"]`, "@media (min-width: 768px)"},
+		{"print:md:flex", `.print\:md\:flex`, "@media (min-width: 768px) $$ @media print"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			util := parse(t, g, tt.token)
+			if util.Selector != tt.selector {
+				t.Errorf("selector = %q, want %q", util.Selector, tt.selector)
+			}
+			if util.Parent != tt.parent {
+				t.Errorf("parent = %q, want %q", util.Parent, tt.parent)
+			}
+		})
+	}
+
+	if utils, _ := g.ParseToken("supports-unknown:flex"); len(utils) != 0 {
+		t.Errorf("ParseToken(%q) = %v, want no utils", "supports-unknown:flex", utils)
+	}
+}
+
+func TestSelectorVariants(t *testing.T) {
+	g := newTestGenerator()
+
+	tests := []struct {
+		token    string
+		selector string
+	}{
+		{"rtl:text-right", `.rtl\:text-right:where([dir="rtl"], [dir="rtl"] *)`},
+		{"ltr:text-left", `.ltr\:text-left:where([dir="ltr"], [dir="ltr"] *)`},
+		{"*:flex", `:is(.\*\:flex > *)`},
+		{"**:flex", `:is(.\*\*\:flex *)`},
+		{"*:before:block", `:is(.\*\:before\:block > *)::before`},
+		{"group-hover:*:flex", `.group:hover :is(.group-hover\:\*\:flex > *)`},
+		// Variantes que envolvem o seletor mantêm a ordem escrita
+		{"*:hover:flex", `:is(.\*\:hover\:flex:hover > *)`},
+		{"hover:*:flex", `:is(.hover\:\*\:flex > *):hover`},
+		{"focus:hover:*:flex", `:is(.focus\:hover\:\*\:flex > *):hover:focus`},
+		{"*:group-hover:flex", `:is(.group:hover .\*\:group-hover\:flex > *)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			if util := parse(t, g, tt.token); util.Selector != tt.selector {
+				t.Errorf("selector = %q, want %q", util.Selector, tt.selector)
+			}
+		})
+	}
+
+	if util := parse(t, g, "md:rtl:flex"); util.Parent != "@media (min-width: 768px)" {
+		t.Errorf("parent = %q, want md breakpoint", util.Parent)
+	}
+}
+
+func TestVariantOrder(t *testing.T) {
+	g := newTestGenerator()
+
+	tests := []struct {
+		token    string
+		selector string
+		parent   string
+	}{
+		{"hover:focus:flex", `.hover\:focus\:flex:hover:focus`, ""},
+		{"focus:hover:flex", `.focus\:hover\:flex:hover:focus`, ""},
+		{"focus:group-hover:flex", `.group:hover .focus\:group-hover\:flex:focus`, ""},
+		{"before:hover:block", `.before\:hover\:block:hover::before`, ""},
+		{"dark:md:flex", `.dark\:md\:flex`, "@media (min-width: 768px) $$ @media (prefers-color-scheme: dark)"},
+		{"md:dark:flex", `.md\:dark\:flex`, "@media (min-width: 768px) $$ @media (prefers-color-scheme: dark)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			util := parse(t, g, tt.token)
+			if util.Selector != tt.selector {
+				t.Errorf("selector = %q, want %q", util.Selector, tt.selector)
+			}
+			if util.Parent != tt.parent {
+				t.Errorf("parent = %q, want %q", util.Parent, tt.parent)
+			}
+		})
+	}
+}
+
+func TestVariantOutputOrder(t *testing.T) {
+	g := newGenerator(&core.Config{
+		Presets:    []core.Preset{NewWind()},
+		Extractors: []core.Extractor{&extractor.ExtractorSplit{}},
+	})
+
+	css, err := g.Generate(map[string]string{"index.html": "disabled:opacity-50 focus:bg-white active:bg-black hover:bg-red-500 group-hover:bg-blue-500 bg-white hover:focus:bg-black"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := []string{
+		`.bg-white {`,
+		`.hover\:bg-red-500:hover {`,
+		`.focus\:bg-white:focus {`,
+		`.hover\:focus\:bg-black:hover:focus {`,
+		`.active\:bg-black:active {`,
+		`.disabled\:opacity-50:disabled {`,
+		`.group:hover .group-hover\:bg-blue-500 {`,
+	}
+	last := -1
+	for _, s := range order {
+		i := strings.Index(css, s)
+		if i <= last {
+			t.Fatalf("Expected %q after previous entries in:\n%s", s, css)
+		}
+		last = i
+	}
+
+	again, _ := g.Generate(map[string]string{"index.html": "group-hover:bg-blue-500 hover:focus:bg-black bg-white disabled:opacity-50 active:bg-black hover:bg-red-500 focus:bg-white"})
+	if again != css {
+		t.Errorf("Expected deterministic output, got:\n%s\nthen:\n%s", css, again)
+	}
+}
+
+func TestCustomVariants(t *testing.T) {
+	g := newGenerator(&core.Config{
+		Presets: []core.Preset{NewWind()},
+		CustomVariants: map[string]string{
+			"theme-midnight": "&:where([data-theme=midnight] *)",
+			"can-hover":      "@media (hover: hover) { &:hover }",
+			"hocus":          "&:hover, &:focus",
+		},
+	})
+
+	util := parse(t, g, "md:theme-midnight:bg-black")
+	if want := `.md\:theme-midnight\:bg-black:where([data-theme=midnight] *)`; util.Selector != want {
+		t.Errorf("selector = %q, want %q", util.Selector, want)
+	}
+	if util.Parent != "@media (min-width: 768px)" {
+		t.Errorf("parent = %q, want md breakpoint", util.Parent)
+	}
+
+	util = parse(t, g, "can-hover:underline")
+	if util.Selector != `.can-hover\:underline:hover` || util.Parent != "@media (hover: hover)" {
+		t.Errorf("util = %q in %q", util.Selector, util.Parent)
+	}
+
+	// As variantes seguintes valem para todos os seletores da lista
+	util = parse(t, g, "hocus:before:block")
+	if want := `:is(.hocus\:before\:block:hover, .hocus\:before\:block:focus)::before`; util.Selector != want {
+		t.Errorf("selector = %q, want %q", util.Selector, want)
+	}
+	util = parse(t, g, "hocus:checked:block")
+	if want := `:is(.hocus\:checked\:block:hover, .hocus\:checked\:block:focus):checked`; util.Selector != want {
+		t.Errorf("selector = %q, want %q", util.Selector, want)
+	}
+}
+
+func TestDeclarativeRules(t *testing.T) {
+	g := newTestGenerator()
+
+	tests := []struct {
+		token   string
+		entries map[string]string
+	}{
+		{"m-4", map[string]string{"margin": "16px"}},
+		{"m-13", map[string]string{"margin": "52px"}},
+		{"m-100", map[string]string{"margin": "400px"}},
+		{"p-15", map[string]string{"padding": "60px"}},
+		{"py-2", map[string]string{"padding-top": "0.5rem", "padding-bottom": "0.5rem"}},
+		{"px-4", map[string]string{"padding-left": "1rem", "padding-right": "1rem"}},
+		{"w-full", map[string]string{"width": "100%"}},
+		{"block", map[string]string{"display": "block"}},
+		{"overflow-x-clip", map[string]string{"overflow-x": "clip"}},
+		{"object-right-top", map[string]string{"object-position": "right top"}},
+		{"float-start", map[string]string{"float": "inline-start"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			if got := parse(t, g, tt.token).Entries; !reflect.DeepEqual(got, tt.entries) {
+				t.Errorf("entries = %v, want %v", got, tt.entries)
+			}
+		})
+	}
+
+	html := parse(t, g, "html")
+	if html.Selector != "html" || html.Layer != "base" {
+		t.Errorf("html = %q in layer %q, want html in base", html.Selector, html.Layer)
+	}
+}
+
+func TestShortcutOutput(t *testing.T) {
+	g := newTestGenerator()
+
+	btn := parse(t, g, "btn")
+	if btn.Selector != ".btn" || btn.Layer != core.DefaultShortcutLayer {
+		t.Errorf("btn = %q in layer %q", btn.Selector, btn.Layer)
+	}
+	if btn.Entries["padding-top"] != "0.5rem" || btn.Entries["font-weight"] != "700" {
+		t.Errorf("btn entries = %v", btn.Entries)
+	}
+	if hover := parse(t, g, "hover:btn-red"); hover.Selector != `.hover\:btn-red:hover` {
+		t.Errorf("hover:btn-red selector = %q", hover.Selector)
+	}
+
+	// A camada dos atalhos vem antes de utilities: p-2 sobrescreve o padding de btn.
+	g = newGenerator(&core.Config{
+		Presets:    []core.Preset{NewWind()},
+		Extractors: []core.Extractor{&extractor.ExtractorSplit{}},
+	})
+	css, err := g.Generate(map[string]string{"index.html": "btn p-2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i, j := strings.Index(css, ".btn {"), strings.Index(css, ".p-2 {"); i < 0 || j < 0 || i > j {
+		t.Errorf("expected .btn before .p-2, got:\n%s", css)
+	}
+}
+
+func TestCustomShortcuts(t *testing.T) {
+	g := newGenerator(&core.Config{
+		Presets: []core.Preset{NewWind()},
+		CustomShortcuts: map[string]string{
+			"btn":           "p-2 font-light",
+			"link":          "text-blue-500 hover:(underline text-blue-700)",
+			"^chip-(\\w+)$": "bg-$1-100 text-$1-800",
+		},
+	})
+
+	tests := []struct {
+		token   string
+		entries map[string]map[string]string // seletor -> declarações
+	}{
+		{"btn", map[string]map[string]string{
+			".btn": {"padding": "8px", "font-weight": "300"},
+		}},
+		{"link", map[string]map[string]string{
+			".link":       {"color": "#3b82f6"},
+			".link:hover": {"text-decoration-line": "underline", "color": "#1d4ed8"},
+		}},
+		{"chip-red", map[string]map[string]string{
+			".chip-red": {"background-color": "#fee2e2", "color": "#991b1b"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			utils, err := g.ParseToken(tt.token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make(map[string]map[string]string)
+			for _, util := range utils {
+				got[util.Selector] = util.Entries
+			}
+			if !reflect.DeepEqual(got, tt.entries) {
+				t.Errorf("got %v, want %v", got, tt.entries)
+			}
+		})
+	}
+}
+
+func TestShortcutVariantSelectors(t *testing.T) {
+	g := newGenerator(&core.Config{
+		Presets: []core.Preset{NewWind()},
+		CustomShortcuts: map[string]string{
+			"field":         "text-sm placeholder:text-red-500",
+			"link":          "text-blue-500 hover:underline",
+			"stack":         "block md:flex",
+			"card":          "p-4 group-hover:underline",
+			"panel":         "p-4 sm:p-8",
+			"^chip-(\\w+)$": "bg-$1-100 hover:bg-$1-200",
+		},
+	})
+
+	type selector struct{ selector, parent string }
+	md := "@media (min-width: 768px)"
+	tests := []struct {
+		token string
+		want  []selector
+	}{
+		{"link", []selector{{".link", ""}, {".link:hover", ""}}},
+		{"hover:field", []selector{{`.hover\:field:hover`, ""}, {`.hover\:field:hover::placeholder`, ""}}},
+		{"md:link", []selector{{`.md\:link`, md}, {`.md\:link:hover`, md}}},
+		{"hover:stack", []selector{{`.hover\:stack:hover`, ""}, {`.hover\:stack:hover`, md}}},
+		{"sm:stack", []selector{{`.sm\:stack`, "@media (min-width: 640px)"}, {`.sm\:stack`, "@media (min-width: 640px) $$ " + md}}},
+		{"focus:card", []selector{{`.focus\:card:focus`, ""}, {`.group:hover .focus\:card:focus`, ""}}},
+		{"dark:link", []selector{{`.dark\:link`, "@media (prefers-color-scheme: dark)"}, {`.dark\:link:hover`, "@media (prefers-color-scheme: dark)"}}},
+		// Variantes e parents repetidos entre o atalho e os membros não se acumulam
+		{"sm:panel", []selector{{`.sm\:panel`, "@media (min-width: 640px)"}}},
+		{"min-[640px]:panel", []selector{{`.min-\[640px\]\:panel`, "@media (min-width: 640px)"}}},
+		{"hover:chip-red", []selector{{`.hover\:chip-red:hover`, ""}}},
+		{"hover:link", []selector{{`.hover\:link:hover`, ""}}},
+		{"hover:*:chip-red", []selector{{`:is(.hover\:\*\:chip-red > *):hover`, ""}, {`:is(.hover\:\*\:chip-red:hover > *):hover`, ""}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			utils, err := g.ParseToken(tt.token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []selector
+			for _, util := range utils {
+				got = append(got, selector{util.Selector, util.Parent})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if util := parse(t, g, "sm:panel"); util.Entries["padding"] != "32px" {
+		t.Errorf("sm:panel entries = %v, want padding 32px", util.Entries)
+	}
+}
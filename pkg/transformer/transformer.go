@@ -0,0 +1,14 @@
+package transformer
+
+import "github.com/su3h7am/gocss/pkg/core"
+
+// VariantGroup implements core.Transformer by expanding variant groups such as
+// `hover:(bg-blue-500 text-white)` into `hover:bg-blue-500 hover:text-white`.
+type VariantGroup struct {
+	// Separators joins the group prefix to each item. Defaults to ":" and "-".
+	Separators []string
+}
+
+func (t *VariantGroup) Transform(code string, path string) string {
+	return core.ExpandVariantGroup(code, t.Separators...)
+}
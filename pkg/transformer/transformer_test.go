@@ -0,0 +1,82 @@
+package transformer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/su3h7am/gocss/pkg/core"
+	"github.com/su3h7am/gocss/pkg/extractor"
+)
+
+func TestVariantGroup(t *testing.T) {
+	tests := []struct {
+		name     string
+		t        *VariantGroup
+		code     string
+		expected string
+	}{
+		{
+			name:     "templ class attribute",
+			t:        &VariantGroup{},
+			code:     `<button class="px-4 hover:(bg-blue-500 text-white)">`,
+			expected: `<button class="px-4 hover:bg-blue-500 hover:text-white">`,
+		},
+		{
+			name:     "custom separators",
+			t:        &VariantGroup{Separators: []string{":"}},
+			code:     `sm:(p-4 m-2) text-(red-500 lg)`,
+			expected: `sm:p-4 sm:m-2 text-(red-500 lg)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.t.Transform(tt.code, "index.templ"); got != tt.expected {
+				t.Errorf("Transform() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestVariantGroupBeforeExtraction(t *testing.T) {
+	cfg, err := core.NewResolvedConfig(&core.Config{
+		Rules: []core.Rule{
+			{
+				Static: "flex",
+				Handler: func(match []string, ctx *core.RuleContext) *core.CSSEntry {
+					return &core.CSSEntry{Properties: map[string]string{"display": "flex"}}
+				},
+				Meta: &core.RuleMeta{Layer: "utilities"},
+			},
+		},
+		Variants: []core.Variant{
+			{
+				Matcher: func(token string, ctx *core.VariantContext) *core.VariantMatch {
+					if strings.HasPrefix(token, "hover:") {
+						return &core.VariantMatch{Matcher: "hover:"}
+					}
+					return nil
+				},
+				Handler: func(entry *core.CSSEntry, match *core.VariantMatch) *core.CSSEntry {
+					entry.Selector += ":hover"
+					return entry
+				},
+			},
+		},
+		Extractors:   []core.Extractor{&extractor.ExtractorSplit{}},
+		Transformers: []core.Transformer{&VariantGroup{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	generator := core.NewGenerator(cfg)
+
+	css, err := generator.Generate(map[string]string{"index.html": "hover:(flex)"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "@layer utilities {\n    .hover\\:flex:hover {\n      display: flex;\n    }\n}\n"
+	if css != expected {
+		t.Errorf("Expected CSS:\n%s\ngot:\n%s", expected, css)
+	}
+}